@@ -1,8 +1,10 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"anvil/internal/ai"
@@ -12,11 +14,14 @@ import (
 )
 
 func main() {
+	seed := flag.Uint64("seed", 0, "seed for dice rolls, 0 picks a random one")
+	flag.Parse()
+
 	dispatcher := eventbus.Dispatcher{}
 	dispatcher.SubscribeAll(func(msg eventbus.Event) {
 		prettyprint.Print(os.Stdout, msg)
 	})
-	gameState := demo.New(&dispatcher)
+	gameState := demo.New(&dispatcher, *seed)
 	encounter := gameState.Encounter
 
 	start := time.Now()
@@ -34,13 +39,18 @@ func main() {
 	}
 	encounter.End()
 	total := time.Since(start)
-	winner, _ := encounter.Winner()
-	if len(winner) == 0 {
-		fmt.Println("All dead")
+	fmt.Println("Seed:", gameState.World.Seed())
+	outcome, _ := encounter.Outcome()
+	if len(outcome.Winners) == 0 {
+		fmt.Println("All dead:", outcome.Reason)
 		return
 	}
 
-	fmt.Println("Winner:", string(winner))
+	names := make([]string, len(outcome.Winners))
+	for i, w := range outcome.Winners {
+		names[i] = string(w)
+	}
+	fmt.Printf("Winner: %s (%s)\n", strings.Join(names, ", "), outcome.Reason)
 	msPerRound := float32(total.Seconds()*1000) / float32(encounter.Round+1)
 	fmt.Printf(
 		"%.2fms (%d rounds %.2fms)\n",
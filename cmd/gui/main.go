@@ -35,6 +35,11 @@ func printOverhead(ev eventbus.Event, overhead *ui.OverheadManager) {
 		pos = data.Target.Position
 		text = fmt.Sprintf("-%d", data.Damage.Value)
 		color = ui.Red
+	case eventbus.EventType(core.HealEvent{}):
+		data := ev.Data.(core.HealEvent)
+		pos = data.Target.Position
+		text = fmt.Sprintf("+%d", data.ActualHealing)
+		color = ui.Green
 	case eventbus.EventType(core.ConditionChangedEvent{}):
 		data := ev.Data.(core.ConditionChangedEvent)
 		pos = data.Source.Position
@@ -97,7 +102,7 @@ func client(_ net.Conn) {
 	defer window.Close()
 	ui.Init()
 	defer ui.Close()
-	gameState := demo.New(&dispatcher)
+	gameState := demo.New(&dispatcher, 0)
 	world := gameState.World
 	encounter := gameState.Encounter
 
@@ -111,8 +116,11 @@ func client(_ net.Conn) {
 		am.SetActive(nil)
 		if encounter.IsOver() {
 			log.AddLine("***** Game over! *****")
-			winner, _ := encounter.Winner()
-			log.AddLine(fmt.Sprintf("%s won!", string(winner)))
+			outcome, _ := encounter.Outcome()
+			for _, winner := range outcome.Winners {
+				log.AddLine(fmt.Sprintf("%s won!", string(winner)))
+			}
+			log.AddLine(outcome.Reason)
 		}
 	}
 
@@ -2,15 +2,25 @@ package ui
 
 import (
 	"anvil/internal/grid"
+	"anvil/internal/mathi"
 )
 
-func DrawHealthbar(pos grid.Position, health, maxHealth int) {
+func DrawHealthbar(pos grid.Position, health, maxHealth, temporary int) {
 	rect := RectFromPos(pos)
 	rect.X += 10
 	rect.Y -= 3
 	rect.Width -= 20
 	rect.Height = 6
 	DrawRectangle(rect, Surface2, 2)
-	rect.Width = int(float64(rect.Width) * (float64(health) / float64(maxHealth)))
+	total := float64(mathi.Max(maxHealth, health+temporary))
+	width := float64(rect.Width)
+	rect.Width = int(width * (float64(health) / total))
 	FillRectangle(rect.Expand(-1, -1), Red)
+	if temporary == 0 {
+		return
+	}
+
+	rect.X += rect.Width
+	rect.Width = int(width * (float64(temporary) / total))
+	FillRectangle(rect.Expand(-1, -1), Sapphire)
 }
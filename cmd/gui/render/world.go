@@ -65,7 +65,7 @@ func drawActor(actor *core.Actor, selected bool) {
 		15,
 		AlignMiddle,
 	)
-	DrawHealthbar(actor.Position, actor.HitPoints, actor.MaxHitPoints)
+	DrawHealthbar(actor.Position, actor.HitPoints, actor.MaxHitPoints, actor.TemporaryHitPoints)
 }
 
 func drawWall(pos grid.Position) {
@@ -0,0 +1,7 @@
+// Package data ships the ruleset files with the binary so they load from any working directory
+package data
+
+import "embed"
+
+//go:embed ruleset
+var Ruleset embed.FS
@@ -1,11 +1,14 @@
 package core
 
 import (
+	"slices"
+
 	"anvil/internal/core/stats"
 	"anvil/internal/core/tags"
 	"anvil/internal/eventbus"
 	"anvil/internal/grid"
 	"anvil/internal/loader"
+	"anvil/internal/mathi"
 	"anvil/internal/tag"
 )
 
@@ -18,14 +21,19 @@ type Actor struct {
 	Proficiencies      stats.Proficiencies
 	SpellCastingSource tag.Tag
 	Name               string
+	Level              int
 	HitPoints          int
 	MaxHitPoints       int
+	TemporaryHitPoints int
 	Actions            []Action
 	Team               TeamID
 	Effects            EffectContainer
 	Equipped           []Item
 	Resources          Resources
 	Conditions         Conditions
+	DamageTraits       DamageTraits
+	Concentration      *Concentration
+	Spellbook          *Spellbook
 }
 
 func (a *Actor) StartTurn() {
@@ -39,6 +47,9 @@ func (a *Actor) EndTurn() {
 
 func (a *Actor) Evaluate(state any) {
 	a.Effects.Evaluate(state)
+	if a.World != nil {
+		a.World.evaluateAuras(a, state)
+	}
 }
 
 func (a *Actor) AddAction(action ...Action) {
@@ -51,8 +62,19 @@ func (a *Actor) AddAction(action ...Action) {
 	}
 }
 
+func (a *Actor) RemoveAction(action Action) {
+	a.Actions = slices.DeleteFunc(a.Actions, func(ca Action) bool { return ca.ID() == action.ID() })
+}
+
+// AddEffect starts the effects' durations, effects they replace under their stacking policy expire first
 func (a *Actor) AddEffect(effect ...*Effect) {
-	a.Effects.Add(effect...)
+	for _, fx := range effect {
+		fx.startDuration(a)
+		for _, replaced := range a.Effects.Replaced(fx) {
+			a.ExpireEffect(replaced)
+		}
+		a.Effects.Add(fx)
+	}
 }
 
 func (a *Actor) RemoveEffect(effect *Effect) {
@@ -79,11 +101,54 @@ func (a *Actor) RemoveCondition(t tag.Tag, src *Effect) {
 	a.Dispatcher.Emit(ConditionChangedEvent{Source: a, From: src, Condition: t, Added: false})
 }
 
+// ChangeConditionLevel raises or lowers a leveled condition, it is removed once the level drops to 0 and exhaustion
+// stops at ExhaustionMaxLevel
+func (a *Actor) ChangeConditionLevel(t tag.Tag, delta int, src *Effect) {
+	old := a.Conditions.Level(t)
+	level := mathi.Max(old+delta, 0)
+	if t.MatchExact(tags.Exhaustion) {
+		level = mathi.Min(level, ExhaustionMaxLevel)
+	}
+	if level == old {
+		return
+	}
+
+	a.Dispatcher.Begin(ConditionLevelChangedEvent{Source: a, Condition: t, From: src, OldLevel: old, Level: level})
+	defer a.Dispatcher.End()
+	a.Conditions.SetLevel(t, level, src)
+	a.Evaluate(&ConditionChanged{Source: a, From: src, Condition: t})
+}
+
+// LongRest restores hit points and resources and ends temporary hit points, effects react to the rest through
+// LongRestFinished
+func (a *Actor) LongRest() {
+	a.Dispatcher.Begin(LongRestEvent{Source: a})
+	defer a.Dispatcher.End()
+	if !a.IsDead() && a.HitPoints < a.MaxHitPoints {
+		a.ModifyAttribute(tags.ActorHitPoints, a.MaxHitPoints-a.HitPoints, "Long Rest")
+	}
+	if a.TemporaryHitPoints > 0 {
+		a.ModifyAttribute(tags.ActorTemporaryHitPoints, -a.TemporaryHitPoints, "Long Rest")
+	}
+	a.Resources.LongRest()
+	a.Evaluate(&LongRestFinished{Source: a})
+}
+
 func (a *Actor) Equip(item Item) {
 	a.Equipped = append(a.Equipped, item)
 	item.OnEquip(a)
 }
 
+func (a *Actor) Unequip(item Item) {
+	idx := slices.IndexFunc(a.Equipped, func(i Item) bool { return i.ID() == item.ID() })
+	if idx == -1 {
+		return
+	}
+
+	a.Equipped = slices.Delete(a.Equipped, idx, idx+1)
+	item.OnUnequip(a)
+}
+
 func (a *Actor) Die() {
 	a.Dispatcher.Begin(DeathEvent{Actor: a})
 	defer a.Dispatcher.End()
@@ -91,6 +156,19 @@ func (a *Actor) Die() {
 	a.Dispatcher.Emit(ConfirmEvent{Confirm: true})
 }
 
+// GrantTemporaryHitPoints replaces the current temporary hit points only when the new amount is higher
+func (a *Actor) GrantTemporaryHitPoints(amount int, reason string) {
+	old := a.TemporaryHitPoints
+	if amount <= old {
+		return
+	}
+
+	a.Dispatcher.Begin(AttributeChangeEvent{Source: a, Attribute: tags.ActorTemporaryHitPoints, OldValue: old, Value: amount, Reason: reason})
+	defer a.Dispatcher.End()
+	a.TemporaryHitPoints = amount
+	a.Evaluate(&AttributeChanged{Source: a, Attribute: tags.ActorTemporaryHitPoints, OldValue: old, Value: amount})
+}
+
 func (a *Actor) ConsumeResource(t tag.Tag, amount int) {
 	a.Resources.Consume(t, amount)
 	a.Dispatcher.Emit(SpendResourceEvent{Source: a, Resource: t, Amount: amount})
@@ -121,14 +199,17 @@ func NewActor(
 		Position:      position,
 		World:         world,
 		Name:          definition.Name,
+		Level:         definition.Level,
 		Team:          team,
 		HitPoints:     definition.HitPoints,
 		MaxHitPoints:  definition.MaxHitPoints,
 		Attributes:    attributes,
 		Proficiencies: proficiencies,
 		Resources:     resources,
+		DamageTraits:  NewDamageTraitsFromDefinition(definition.Resistances, definition.Vulnerabilities, definition.Immunities),
 	}
 
+	actor.Spellbook = NewSpellbookFromDefinition(actor, definition.Spells)
 	if definition.SpellCastingSource != "" {
 		actor.SpellCastingSource = tag.FromString(definition.SpellCastingSource)
 	}
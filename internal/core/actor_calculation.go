@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"math"
 
 	"anvil/internal/core/stats"
@@ -13,6 +14,7 @@ import (
 
 func (a *Actor) ArmorClass() *expression.Expression {
 	expr := expression.FromConstant(10, "Base")
+	expr.Rng = a.Roller()
 	dex := a.Attribute(tags.AttributeDexterity)
 	expr.AddConstant(stats.AttributeModifier(dex.Value), "Attribute Modifier", dex.Components...)
 	s := AttributeCalculation{
@@ -27,6 +29,7 @@ func (a *Actor) ArmorClass() *expression.Expression {
 
 func (a *Actor) Attribute(t tag.Tag) *expression.Expression {
 	expr := expression.FromConstant(a.Attributes.Value(t), tags.ToReadable(t))
+	expr.Rng = a.Roller()
 	s := AttributeCalculation{
 		Expression: expr,
 		Attribute:  t,
@@ -40,21 +43,78 @@ func (a *Actor) Proficiency(tags tag.Container) int {
 	return a.Proficiencies.Value(tags)
 }
 
+// ModifyAttribute adds val to a stored attribute, hit points are kept between 0 and the maximum
 func (a *Actor) ModifyAttribute(t tag.Tag, val int, reason string) {
+	field := a.attributeField(t)
+	if field == nil {
+		panic(fmt.Sprintf("ModifyAttribute not implemented for %s", t.AsString()))
+	}
+
+	old := *field
+	value := mathi.Max(old+val, 0)
 	if t.MatchExact(tags.ActorHitPoints) {
-		old := a.HitPoints
-		a.Dispatcher.Begin(AttributeChangeEvent{Source: a, Attribute: t, OldValue: old, Value: old + val, Reason: reason})
-		defer a.Dispatcher.End()
-		a.HitPoints = val
-		a.Evaluate(&AttributeChanged{Source: a, Attribute: t, OldValue: old, Value: old + val})
+		value = mathi.Min(value, a.MaxHitPoints)
+	}
+
+	a.Dispatcher.Begin(AttributeChangeEvent{Source: a, Attribute: t, OldValue: old, Value: value, Reason: reason})
+	defer a.Dispatcher.End()
+	*field = value
+	a.Evaluate(&AttributeChanged{Source: a, Attribute: t, OldValue: old, Value: value})
+}
+
+func (a *Actor) attributeField(t tag.Tag) *int {
+	switch {
+	case t.MatchExact(tags.ActorHitPoints):
+		return &a.HitPoints
+	case t.MatchExact(tags.ActorTemporaryHitPoints):
+		return &a.TemporaryHitPoints
+	default:
+		return a.Attributes.Field(t)
+	}
+}
+
+// Heal restores hit points up to the maximum, dead actors can't be healed
+func (a *Actor) Heal(healing expression.Expression) {
+	if a.IsDead() {
 		return
 	}
 
-	panic("ModifyAttribute not implemented")
+	expr := healing.Clone()
+	expr.Rng = a.Roller()
+	before := PreHeal{Expression: expr, Source: a}
+	a.Evaluate(&before)
+	res := expr.Evaluate()
+	old := a.HitPoints
+	a.HitPoints = mathi.Clamp(old+res.Value, old, a.MaxHitPoints)
+	actual := a.HitPoints - old
+	a.Dispatcher.Begin(HealEvent{Target: a, Healing: res, ActualHealing: actual})
+	defer a.Dispatcher.End()
+	a.Evaluate(&AttributeChanged{Source: a, Attribute: tags.ActorHitPoints, OldValue: old, Value: a.HitPoints})
+	after := PostHeal{Result: res, Source: a, ActualHealing: actual}
+	a.Evaluate(&after)
+}
+
+// RollInitiative is a Dexterity check, effects on ability checks apply before the ones on initiative
+func (a *Actor) RollInitiative() int {
+	expr := expression.FromD20("Base")
+	expr.Rng = a.Roller()
+	a.Dispatcher.Begin(InitiativeRollEvent{Source: a, Expression: expr})
+	defer a.Dispatcher.End()
+	check := PreAbilityCheck{Expression: expr, Source: a, Attribute: tags.AttributeDexterity}
+	a.Evaluate(&check)
+	before := PreInitiative{Source: a, Expression: expr}
+	a.Evaluate(&before)
+	expr.Evaluate()
+	a.Evaluate(&PostAbilityCheck{Result: expr, Source: a, Attribute: tags.AttributeDexterity})
+	after := PostInitiative{Source: a, Result: expr}
+	a.Evaluate(&after)
+	a.Dispatcher.Emit(ExpressionResultEvent{Expression: expr})
+	return expr.Value
 }
 
 func (a *Actor) SaveThrow(t tag.Tag, dc int) CheckResult {
 	expr := expression.FromD20("Base")
+	expr.Rng = a.Roller()
 	before := PreSavingThrow{Expression: expr, Source: a, Attribute: t, DifficultyClass: dc}
 	a.Dispatcher.Begin(SavingThrowEvent{Expression: expr, Source: a, Attribute: t, DifficultyClass: dc})
 	defer a.Dispatcher.End()
@@ -71,33 +131,123 @@ func (a *Actor) SaveThrow(t tag.Tag, dc int) CheckResult {
 	if after.Result.IsCriticalFailure() {
 		crit = true
 	}
+	if before.AutoFail {
+		crit = false
+		success = false
+	}
 	a.Dispatcher.Emit(ExpressionResultEvent{Expression: expr})
 	a.Dispatcher.Emit(SavingThrowResultEvent{Actor: a, Value: expr.Value, Against: dc, Critical: crit, Success: success})
 	return CheckResult{Value: expr.Value, Against: dc, Critical: crit, Success: success}
 }
 
+// AbilityCheck rolls attribute against dc, skill is optional and adds its proficiency
+func (a *Actor) AbilityCheck(attribute tag.Tag, skill tag.Tag, dc int) CheckResult {
+	expr := expression.FromD20("Base")
+	a.Dispatcher.Begin(AbilityCheckEvent{Expression: expr, Source: a, Attribute: attribute, Skill: skill, DifficultyClass: dc})
+	defer a.Dispatcher.End()
+	value, ok := a.rollAbilityCheck(expr, attribute, skill, dc)
+	success := ok && value >= dc
+	a.Dispatcher.Emit(CheckResultEvent{Actor: a, Value: value, Against: dc, Success: success, Tags: checkTags(attribute, skill)})
+	return CheckResult{Value: value, Against: dc, Success: success}
+}
+
+// ContestedCheck pits an ability check against one from other, ties go to other
+func (a *Actor) ContestedCheck(other *Actor, attribute tag.Tag, skill tag.Tag, otherAttribute tag.Tag, otherSkill tag.Tag) CheckResult {
+	a.Dispatcher.Begin(ContestedCheckEvent{Source: a, Target: other})
+	defer a.Dispatcher.End()
+	value, ok := a.rollContestedSide(attribute, skill)
+	against, otherOK := other.rollContestedSide(otherAttribute, otherSkill)
+	success := ok && (!otherOK || value > against)
+	a.Dispatcher.Emit(CheckResultEvent{Actor: a, Value: value, Against: against, Success: success, Tags: checkTags(attribute, skill)})
+	return CheckResult{Value: value, Against: against, Success: success}
+}
+
+// rollContestedSide rolls one side of a contest as its own ability check so both rolls show up
+func (a *Actor) rollContestedSide(attribute tag.Tag, skill tag.Tag) (int, bool) {
+	expr := expression.FromD20("Base")
+	a.Dispatcher.Begin(AbilityCheckEvent{Expression: expr, Source: a, Attribute: attribute, Skill: skill})
+	defer a.Dispatcher.End()
+	return a.rollAbilityCheck(expr, attribute, skill, 0)
+}
+
+func (a *Actor) rollAbilityCheck(expr *expression.Expression, attribute tag.Tag, skill tag.Tag, dc int) (int, bool) {
+	expr.Rng = a.Roller()
+	before := PreAbilityCheck{Expression: expr, Source: a, Attribute: attribute, Skill: skill, DifficultyClass: dc}
+	a.Evaluate(&before)
+	expr.Evaluate()
+	after := PostAbilityCheck{Result: expr, Source: a, Attribute: attribute, Skill: skill, DifficultyClass: dc}
+	a.Evaluate(&after)
+	a.Dispatcher.Emit(ExpressionResultEvent{Expression: expr})
+	return after.Result.Value, !before.AutoFail
+}
+
+// PassiveScore is 10 plus the check modifiers, advantage adds 5 and disadvantage subtracts 5
+func (a *Actor) PassiveScore(attribute tag.Tag, skill tag.Tag) int {
+	expr := expression.FromD20("Base")
+	before := PreAbilityCheck{Expression: expr, Source: a, Attribute: attribute, Skill: skill, Passive: true}
+	a.Evaluate(&before)
+	score := 10
+	for _, c := range expr.Components[1:] {
+		score += c.Expected()
+	}
+
+	d20 := expr.Components[0].(*expression.D20Component)
+	advantage, disadvantage := len(d20.Advantage()) > 0, len(d20.Disadvantage()) > 0
+	switch {
+	case advantage && !disadvantage:
+		score += 5
+	case disadvantage && !advantage:
+		score -= 5
+	}
+	return score
+}
+
+func checkTags(attribute tag.Tag, skill tag.Tag) tag.Container {
+	tc := tag.ContainerFromTag(attribute)
+	if !skill.IsEmpty() {
+		tc.AddTag(skill)
+	}
+	return tc
+}
+
 func (a *Actor) TakeDamage(damage expression.Expression) {
 	expr := expression.FromDamageResult(damage)
+	expr.Rng = a.Roller()
 	before := PreTakeDamage{Expression: expr, Source: a}
 	a.Evaluate(&before)
-	res := expr.Evaluate()
-	actual := a.HitPoints - mathi.Clamp(a.HitPoints-res.Value, 0, math.MaxInt)
+	res := a.EffectiveDamageTraits().Apply(expr.EvaluateDamage())
+	temporary := mathi.Clamp(res.Value, 0, a.TemporaryHitPoints)
+	a.TemporaryHitPoints -= temporary
+	actual := a.HitPoints - mathi.Clamp(a.HitPoints-(res.Value-temporary), 0, math.MaxInt)
 	a.HitPoints = mathi.Clamp(a.HitPoints-actual, 0, math.MaxInt)
-	a.Dispatcher.Begin(TakeDamageEvent{Target: a, Damage: expr})
-	after := PostTakeDamage{Result: res, Source: a, ActualDamage: actual}
-	a.Effects.Evaluate(&after)
-	a.Dispatcher.End()
+	a.Dispatcher.Begin(TakeDamageEvent{Target: a, Damage: res, TemporaryDamage: temporary, ActualDamage: actual})
+	defer a.Dispatcher.End()
+	if temporary > 0 && a.TemporaryHitPoints == 0 {
+		a.Evaluate(&TemporaryHitPointsDepleted{Source: a, Result: res})
+	}
+
+	after := PostTakeDamage{Result: res, Source: a, ActualDamage: actual, TemporaryDamage: temporary}
+	a.Evaluate(&after)
+	a.react(&after)
+}
+
+func (a *Actor) EffectiveDamageTraits() DamageTraits {
+	s := DamageTraitsCalculation{Source: a, Traits: a.DamageTraits.Clone()}
+	a.Evaluate(&s)
+	return s.Traits
 }
 
 func (a *Actor) AttackRoll(target *Actor, tc tag.Container) CheckResult {
 	expr := expression.FromD20("Base")
+	expr.Rng = a.Roller()
 	a.Dispatcher.Begin(AttackRollEvent{Source: a, Target: target})
 	defer a.Dispatcher.End()
 	before := PreAttackRoll{Source: a, Target: target, Expression: expr, Tags: tc}
-	a.Effects.Evaluate(&before)
+	a.Evaluate(&before)
 	expr.Evaluate()
 	after := PostAttackRoll{Source: a, Target: target, Result: expr, Tags: tc}
-	a.Effects.Evaluate(&after)
+	a.Evaluate(&after)
+	a.react(&after)
 	a.Dispatcher.Emit(ExpressionResultEvent{Expression: expr})
 	value := after.Result.Value
 	targetAC := target.ArmorClass()
@@ -112,20 +262,31 @@ func (a *Actor) AttackRoll(target *Actor, tc tag.Container) CheckResult {
 		crit = true
 		hit = false
 	}
+	if hit && before.CriticalOnHit {
+		crit = true
+	}
 	a.Dispatcher.Emit(CheckResultEvent{Actor: a, Value: value, Against: targetAC.Value, Critical: crit, Success: hit, Tags: tc})
 	return CheckResult{Value: value, Against: targetAC.Value, Critical: crit, Success: hit}
 }
 
+// CanAttack is false when an effect, such as being charmed, forbids attacking the target
+func (a *Actor) CanAttack(target *Actor) bool {
+	s := AttackAllowed{Source: a, Target: target, Allowed: true}
+	a.Evaluate(&s)
+	return s.Allowed
+}
+
 func (a *Actor) DamageRoll(ds DamageSource, crit bool) *expression.Expression {
 	expr := ds.Damage().Clone()
+	expr.Rng = a.Roller()
 	a.Dispatcher.Begin(DamageRollEvent{Source: a, DamageSource: ds})
 	defer a.Dispatcher.End()
 	before := PreDamageRoll{Source: a, Expression: expr, Tags: *ds.Tags(), Critical: crit}
-	a.Effects.Evaluate(&before)
+	a.Evaluate(&before)
 	res := expr.EvaluateDamage()
 	a.Dispatcher.Emit(ExpressionResultEvent{Expression: res})
 	after := PostDamageRoll{Source: a, Result: res, Tags: *ds.Tags(), Critical: crit}
-	a.Effects.Evaluate(&after)
+	a.Evaluate(&after)
 	return res
 }
 
@@ -139,11 +300,14 @@ func (a *Actor) Move(to grid.Position, action Action) {
 		CanMove: true,
 		Action:  action,
 	}
-	a.Effects.Evaluate(&before)
+	a.Evaluate(&before)
+	a.react(&before)
+	before.CanMove = before.CanMove && a.CanAct()
 	a.Dispatcher.Emit(ConfirmEvent{Actor: a, Confirm: before.CanMove})
 	if before.CanMove {
 		a.World.RemoveOccupant(a.Position, a)
 		a.Position = to
 		a.World.AddOccupant(to, a)
+		a.World.UpdateAuras()
 	}
 }
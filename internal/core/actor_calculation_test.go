@@ -0,0 +1,364 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/eventbus"
+	"anvil/internal/expression"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+	"anvil/internal/tag"
+)
+
+type sequenceRoller struct {
+	values []int
+	index  int
+}
+
+func (r *sequenceRoller) Roll(_ int) int {
+	if r.index >= len(r.values) {
+		return 1
+	}
+
+	value := r.values[r.index]
+	r.index++
+	return value
+}
+
+func newTestActor(world *World, name string, pos grid.Position) *Actor {
+	actor := NewActor(&eventbus.Dispatcher{}, world, pos, loader.ActorDefinition{
+		Name:         name,
+		HitPoints:    10,
+		MaxHitPoints: 10,
+		Attributes:   loader.AttributesDefinition{Dexterity: 10},
+	})
+	return actor
+}
+
+func TestActor_CriticalRange(t *testing.T) {
+	expand := func() *Effect {
+		fx := &Effect{Name: "Improved Critical"}
+		On(fx, func(s *PreAttackRoll) { s.Expression.ExpandCriticalRange(19, fx.Name) })
+		On(fx, func(s *PreSavingThrow) { s.Expression.ExpandCriticalRange(19, fx.Name) })
+		return fx
+	}
+
+	t.Run("attack roll crits inside the expanded range", func(t *testing.T) {
+		world := NewWorld(loader.WorldDefinition{Width: 3, Height: 3})
+		world.SetRoller(&sequenceRoller{values: []int{19}})
+		attacker := newTestActor(world, "attacker", grid.Position{X: 0, Y: 0})
+		target := newTestActor(world, "target", grid.Position{X: 1, Y: 0})
+		attacker.AddEffect(expand())
+
+		result := attacker.AttackRoll(target, attacker.Proficiencies.Skills)
+
+		assert.True(t, result.Critical)
+		assert.True(t, result.Success)
+	})
+
+	t.Run("attack roll without the effect is a normal roll", func(t *testing.T) {
+		world := NewWorld(loader.WorldDefinition{Width: 3, Height: 3})
+		world.SetRoller(&sequenceRoller{values: []int{19}})
+		attacker := newTestActor(world, "attacker", grid.Position{X: 0, Y: 0})
+		target := newTestActor(world, "target", grid.Position{X: 1, Y: 0})
+
+		result := attacker.AttackRoll(target, attacker.Proficiencies.Skills)
+
+		assert.False(t, result.Critical)
+		assert.True(t, result.Success)
+	})
+
+	t.Run("saving throw crits inside the expanded range", func(t *testing.T) {
+		world := NewWorld(loader.WorldDefinition{Width: 3, Height: 3})
+		world.SetRoller(&sequenceRoller{values: []int{19}})
+		actor := newTestActor(world, "actor", grid.Position{X: 0, Y: 0})
+		actor.AddEffect(expand())
+
+		result := actor.SaveThrow(tags.AttributeDexterity, 25)
+
+		assert.True(t, result.Critical)
+		assert.True(t, result.Success)
+	})
+}
+
+func TestActor_ForcedOutcomes(t *testing.T) {
+	t.Run("auto fail overrides a natural 20", func(t *testing.T) {
+		world := NewWorld(loader.WorldDefinition{Width: 3, Height: 3})
+		world.SetRoller(&sequenceRoller{values: []int{20}})
+		actor := newTestActor(world, "actor", grid.Position{})
+		fx := &Effect{Name: "Paralyzed"}
+		On(fx, func(s *PreSavingThrow) { s.AutoFail = true })
+		actor.AddEffect(fx)
+
+		result := actor.SaveThrow(tags.AttributeDexterity, 5)
+
+		assert.False(t, result.Success)
+		assert.False(t, result.Critical)
+	})
+
+	t.Run("hits become critical", func(t *testing.T) {
+		world := NewWorld(loader.WorldDefinition{Width: 3, Height: 3})
+		world.SetRoller(&sequenceRoller{values: []int{15, 2}})
+		attacker := newTestActor(world, "attacker", grid.Position{X: 0, Y: 0})
+		target := newTestActor(world, "target", grid.Position{X: 1, Y: 0})
+		fx := &Effect{Name: "Paralyzed"}
+		On(fx, func(s *PreAttackRoll) { s.CriticalOnHit = true })
+		attacker.AddEffect(fx)
+
+		hit := attacker.AttackRoll(target, attacker.Proficiencies.Skills)
+		miss := attacker.AttackRoll(target, attacker.Proficiencies.Skills)
+
+		assert.True(t, hit.Critical)
+		assert.False(t, miss.Success)
+		assert.False(t, miss.Critical)
+	})
+
+	t.Run("effects can forbid attacking a target", func(t *testing.T) {
+		world := NewWorld(loader.WorldDefinition{Width: 3, Height: 3})
+		attacker := newTestActor(world, "attacker", grid.Position{X: 0, Y: 0})
+		target := newTestActor(world, "target", grid.Position{X: 1, Y: 0})
+		fx := &Effect{Name: "Charmed"}
+		On(fx, func(s *AttackAllowed) { s.Allowed = s.Target != target })
+		attacker.AddEffect(fx)
+
+		assert.False(t, attacker.CanAttack(target))
+		assert.True(t, target.CanAttack(attacker))
+	})
+}
+
+func TestActor_AbilityCheck(t *testing.T) {
+	newActor := func(rolls ...int) *Actor {
+		world := NewWorld(loader.WorldDefinition{Width: 3, Height: 3})
+		world.SetRoller(&sequenceRoller{values: rolls})
+		return newTestActor(world, "actor", grid.Position{})
+	}
+	bonus := func(value int) *Effect {
+		fx := &Effect{Name: "Guidance"}
+		On(fx, func(s *PreAbilityCheck) { s.Expression.AddConstant(value, fx.Name) })
+		return fx
+	}
+
+	t.Run("succeeds at the difficulty class", func(t *testing.T) {
+		actor := newActor(10)
+		actor.AddEffect(bonus(2))
+
+		result := actor.AbilityCheck(tags.AttributeStrength, tags.ProficiencyAthletics, 12)
+
+		assert.True(t, result.Success)
+		assert.Equal(t, 12, result.Value)
+	})
+
+	t.Run("a natural 20 is not a critical", func(t *testing.T) {
+		actor := newActor(20)
+
+		result := actor.AbilityCheck(tags.AttributeStrength, tag.Tag{}, 25)
+
+		assert.False(t, result.Success)
+		assert.False(t, result.Critical)
+	})
+
+	t.Run("auto fail", func(t *testing.T) {
+		actor := newActor(20)
+		fx := &Effect{Name: "Blinded"}
+		On(fx, func(s *PreAbilityCheck) { s.AutoFail = true })
+		actor.AddEffect(fx)
+
+		assert.False(t, actor.AbilityCheck(tags.AttributeWisdom, tags.ProficiencyPerception, 5).Success)
+	})
+
+	t.Run("contested checks go to the higher total", func(t *testing.T) {
+		actor := newActor(12, 10)
+		other := newTestActor(actor.World, "other", grid.Position{X: 1})
+		other.AddEffect(bonus(2))
+
+		result := actor.ContestedCheck(other, tags.AttributeStrength, tags.ProficiencyAthletics, tags.AttributeDexterity, tags.ProficiencyAcrobatics)
+
+		assert.False(t, result.Success, "ties go to the defender")
+		assert.Equal(t, 12, result.Value)
+		assert.Equal(t, 12, result.Against)
+	})
+
+	t.Run("each side of a contest rolls its own ability check", func(t *testing.T) {
+		actor := newActor(12, 10)
+		other := newTestActor(actor.World, "other", grid.Position{X: 1})
+		var rolled []*Actor
+		record := func(e eventbus.Event) {
+			if !e.End {
+				rolled = append(rolled, e.Data.(AbilityCheckEvent).Source)
+			}
+		}
+		actor.Dispatcher.(*eventbus.Dispatcher).Subscribe(eventbus.EventType(AbilityCheckEvent{}), record)
+		other.Dispatcher.(*eventbus.Dispatcher).Subscribe(eventbus.EventType(AbilityCheckEvent{}), record)
+
+		actor.ContestedCheck(other, tags.AttributeStrength, tags.ProficiencyAthletics, tags.AttributeDexterity, tags.ProficiencyAcrobatics)
+
+		assert.Equal(t, []*Actor{actor, other}, rolled)
+	})
+
+	t.Run("initiative is a dexterity check", func(t *testing.T) {
+		actor := newActor(4)
+		var attribute tag.Tag
+		fx := bonus(2)
+		On(fx, func(s *PostAbilityCheck) { attribute = s.Attribute })
+		actor.AddEffect(fx)
+
+		assert.Equal(t, 6, actor.RollInitiative())
+		assert.Equal(t, tags.AttributeDexterity, attribute)
+	})
+
+	t.Run("passive score is 10 plus modifiers", func(t *testing.T) {
+		actor := newActor()
+		actor.AddEffect(bonus(3))
+		assert.Equal(t, 13, actor.PassiveScore(tags.AttributeWisdom, tags.ProficiencyPerception))
+
+		fx := &Effect{Name: "Keen Senses"}
+		On(fx, func(s *PreAbilityCheck) { s.Expression.GiveAdvantage(fx.Name) })
+		actor.AddEffect(fx)
+		assert.Equal(t, 18, actor.PassiveScore(tags.AttributeWisdom, tags.ProficiencyPerception))
+	})
+}
+
+func TestActor_TemporaryHitPoints(t *testing.T) {
+	newActor := func() *Actor {
+		return newTestActor(NewWorld(loader.WorldDefinition{Width: 3, Height: 3}), "actor", grid.Position{})
+	}
+
+	t.Run("keeps the higher amount", func(t *testing.T) {
+		actor := newActor()
+		actor.GrantTemporaryHitPoints(5, "False Life")
+		actor.GrantTemporaryHitPoints(3, "Heroism")
+
+		assert.Equal(t, 5, actor.TemporaryHitPoints)
+
+		actor.GrantTemporaryHitPoints(8, "Armor of Agathys")
+		assert.Equal(t, 8, actor.TemporaryHitPoints)
+	})
+
+	t.Run("absorbs damage before hit points", func(t *testing.T) {
+		actor := newActor()
+		actor.GrantTemporaryHitPoints(5, "False Life")
+		var after PostTakeDamage
+		fx := &Effect{Name: "spy"}
+		On(fx, func(s *PostTakeDamage) { after = *s })
+		actor.AddEffect(fx)
+
+		actor.TakeDamage(*expression.FromDamageConstant(7, tag.ContainerFromTag(tags.Fire), "test").EvaluateDamage())
+
+		assert.Equal(t, 0, actor.TemporaryHitPoints)
+		assert.Equal(t, 8, actor.HitPoints)
+		assert.Equal(t, 5, after.TemporaryDamage)
+		assert.Equal(t, 2, after.ActualDamage)
+	})
+
+	t.Run("notifies effects when depleted", func(t *testing.T) {
+		actor := newActor()
+		actor.GrantTemporaryHitPoints(5, "Armor of Agathys")
+		depleted := 0
+		fx := &Effect{Name: "spy"}
+		On(fx, func(_ *TemporaryHitPointsDepleted) { depleted++ })
+		actor.AddEffect(fx)
+
+		actor.TakeDamage(*expression.FromDamageConstant(3, tag.ContainerFromTag(tags.Fire), "test").EvaluateDamage())
+		assert.Equal(t, 0, depleted)
+
+		actor.TakeDamage(*expression.FromDamageConstant(3, tag.ContainerFromTag(tags.Fire), "test").EvaluateDamage())
+		assert.Equal(t, 1, depleted)
+		assert.Equal(t, 9, actor.HitPoints)
+	})
+}
+
+func TestActor_Heal(t *testing.T) {
+	newActor := func(hitPoints int) *Actor {
+		actor := newTestActor(NewWorld(loader.WorldDefinition{Width: 3, Height: 3}), "actor", grid.Position{})
+		actor.HitPoints = hitPoints
+		return actor
+	}
+
+	t.Run("restores hit points", func(t *testing.T) {
+		actor := newActor(4)
+		actor.Heal(*expression.FromConstant(3, "Cure Wounds"))
+
+		assert.Equal(t, 7, actor.HitPoints)
+	})
+
+	t.Run("clamps to max hit points", func(t *testing.T) {
+		actor := newActor(8)
+		var after PostHeal
+		fx := &Effect{Name: "spy"}
+		On(fx, func(s *PostHeal) { after = *s })
+		actor.AddEffect(fx)
+
+		actor.Heal(*expression.FromConstant(5, "Potion of Healing"))
+
+		assert.Equal(t, 10, actor.HitPoints)
+		assert.Equal(t, 5, after.Result.Value)
+		assert.Equal(t, 2, after.ActualHealing)
+	})
+
+	t.Run("effects can modify healing", func(t *testing.T) {
+		actor := newActor(1)
+		fx := &Effect{Name: "Disciple of Life"}
+		On(fx, func(s *PreHeal) { s.Expression.AddConstant(3, fx.Name) })
+		actor.AddEffect(fx)
+
+		actor.Heal(*expression.FromConstant(2, "Cure Wounds"))
+
+		assert.Equal(t, 6, actor.HitPoints)
+	})
+
+	t.Run("notifies a recovery from 0 hit points", func(t *testing.T) {
+		actor := newActor(0)
+		var changed AttributeChanged
+		fx := &Effect{Name: "spy"}
+		On(fx, func(s *AttributeChanged) { changed = *s })
+		actor.AddEffect(fx)
+
+		actor.Heal(*expression.FromConstant(2, "Second Wind"))
+
+		assert.Equal(t, 0, changed.OldValue)
+		assert.Equal(t, 2, changed.Value)
+	})
+
+	t.Run("dead actors are not healed", func(t *testing.T) {
+		actor := newActor(0)
+		actor.AddCondition(tags.Dead, &Effect{Name: "Dead"})
+
+		actor.Heal(*expression.FromConstant(2, "Cure Wounds"))
+
+		assert.Equal(t, 0, actor.HitPoints)
+	})
+}
+
+func TestActor_ModifyAttribute(t *testing.T) {
+	actor := newTestActor(NewWorld(loader.WorldDefinition{Width: 3, Height: 3}), "actor", grid.Position{})
+	actor.HitPoints = 0
+
+	actor.ModifyAttribute(tags.ActorHitPoints, 1, "Undead Fortitude")
+	assert.Equal(t, 1, actor.HitPoints)
+
+	actor.ModifyAttribute(tags.ActorHitPoints, 20, "test")
+	assert.Equal(t, 10, actor.HitPoints)
+
+	actor.ModifyAttribute(tags.AttributeStrength, 2, "Belt of Giant Strength")
+	assert.Equal(t, 2, actor.Attributes.Strength)
+
+	assert.Panics(t, func() { actor.ModifyAttribute(tags.ActorArmorClass, 1, "test") })
+}
+
+func BenchmarkActor_Attribute(b *testing.B) {
+	actor := newTestActor(NewWorld(loader.WorldDefinition{Width: 3, Height: 3}), "actor", grid.Position{})
+	for i := range 10 {
+		fx := &Effect{Name: "bonus", Priority: Priority(i)}
+		On(fx, func(s *AttributeCalculation) { s.Expression.AddConstant(1, fx.Name) })
+		On(fx, func(s *PreAttackRoll) {})
+		actor.AddEffect(fx)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		actor.Attribute(tags.AttributeStrength)
+	}
+}
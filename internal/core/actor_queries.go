@@ -1,8 +1,11 @@
 package core
 
 import (
+	"slices"
+
 	"anvil/internal/core/stats"
 	"anvil/internal/core/tags"
+	"anvil/internal/expression"
 	"anvil/internal/tag"
 )
 
@@ -28,6 +31,21 @@ func (a Actor) MatchCondition(t tag.Tag) bool {
 	return a.Conditions.Match(t)
 }
 
+func (a Actor) ConditionLevel(t tag.Tag) int {
+	return a.Conditions.Level(t)
+}
+
+// ConditionSources are the actors that placed a condition, such as the one a creature is charmed by
+func (a Actor) ConditionSources(t tag.Tag) []*Actor {
+	var sources []*Actor
+	for _, fx := range a.Conditions.Sources[t] {
+		if fx.Source != nil && !slices.Contains(sources, fx.Source) {
+			sources = append(sources, fx.Source)
+		}
+	}
+	return sources
+}
+
 func (a Actor) IsDead() bool {
 	return a.HasCondition(tags.Dead, nil)
 }
@@ -40,13 +58,26 @@ func (a Actor) SpellSaveDC() int {
 	return 8 + a.Proficiencies.Bonus + stats.AttributeModifier(a.Attribute(a.SpellCastingSource).Value)
 }
 
+func (a Actor) Roller() expression.Roller {
+	if a.World == nil {
+		return nil
+	}
+
+	return a.World.Roller()
+}
+
 func (a Actor) IsHostileTo(o *Actor) bool {
-	return a.Team != o.Team
+	var factions *Factions
+	if a.Encounter != nil {
+		factions = a.Encounter.Factions
+	}
+
+	return factions.Relationship(a.Team, o.Team) == Hostile
 }
 
 func (a Actor) HasAction(aa Action) bool {
 	for _, ca := range a.Actions {
-		if ca.Name() == aa.Name() {
+		if ca.ID() == aa.ID() {
 			return true
 		}
 	}
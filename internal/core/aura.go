@@ -0,0 +1,156 @@
+package core
+
+import (
+	"slices"
+
+	"anvil/internal/grid"
+)
+
+type AuraShape string
+
+const (
+	// AuraEmanation covers every cell within Radius, diagonals count as one step
+	AuraEmanation AuraShape = ""
+	AuraCircle    AuraShape = "circle"
+	// AuraWorld covers the whole map, like a lair
+	AuraWorld AuraShape = "world"
+)
+
+// Aura applies the handlers of its effect to every actor inside it, it follows its source actor unless it
+// is anchored to cells
+type Aura struct {
+	Name   string
+	Source *Actor
+	Cells  []grid.Position
+	Shape  AuraShape
+	Radius int
+	Effect *Effect
+	inside []*Actor
+}
+
+func (a *Aura) Contains(pos grid.Position) bool {
+	if a.Shape == AuraWorld {
+		return true
+	}
+
+	if len(a.Cells) > 0 {
+		return slices.Contains(a.Cells, pos)
+	}
+
+	if a.Source == nil {
+		return false
+	}
+
+	if a.Shape == AuraCircle {
+		d := pos.Subtract(a.Source.Position)
+		return d.X*d.X+d.Y*d.Y <= a.Radius*a.Radius
+	}
+
+	return pos.Distance(a.Source.Position) <= a.Radius
+}
+
+func (a *Aura) Inside() []*Actor {
+	return a.inside
+}
+
+func (a *Aura) IsInside(actor *Actor) bool {
+	return slices.Contains(a.inside, actor)
+}
+
+func (a *Aura) candidates(w *World) []grid.Position {
+	switch {
+	case a.Shape == AuraWorld:
+		return w.positions()
+	case len(a.Cells) > 0:
+		return a.Cells
+	case a.Source == nil:
+		return nil
+	}
+
+	center := a.Source.Position
+	positions := make([]grid.Position, 0, (2*a.Radius+1)*(2*a.Radius+1))
+	for y := -a.Radius; y <= a.Radius; y++ {
+		for x := -a.Radius; x <= a.Radius; x++ {
+			positions = append(positions, grid.Position{X: center.X + x, Y: center.Y + y})
+		}
+	}
+	return positions
+}
+
+func (a *Aura) enter(actor *Actor) {
+	actor.Dispatcher.Begin(AuraChangedEvent{Aura: a, Actor: actor, Entered: true})
+	defer actor.Dispatcher.End()
+	actor.Evaluate(&AuraEntered{Aura: a, Source: actor})
+}
+
+func (a *Aura) leave(actor *Actor) {
+	actor.Dispatcher.Begin(AuraChangedEvent{Aura: a, Actor: actor, Entered: false})
+	defer actor.Dispatcher.End()
+	state := &AuraLeft{Aura: a, Source: actor}
+	a.Effect.Evaluate(state)
+	actor.Evaluate(state)
+}
+
+func (w *World) AddAura(aura *Aura) {
+	w.auras = append(w.auras, aura)
+	w.UpdateAuras()
+}
+
+func (w *World) RemoveAura(aura *Aura) {
+	w.auras = slices.DeleteFunc(slices.Clone(w.auras), func(a *Aura) bool { return a == aura })
+	inside := aura.inside
+	aura.inside = nil
+	for _, actor := range inside {
+		aura.leave(actor)
+	}
+}
+
+func (w *World) Auras() []*Aura {
+	return w.auras
+}
+
+// UpdateAuras recomputes who stands in every aura and fires the enter and leave states, it runs whenever
+// an actor moves, joins or leaves
+func (w *World) UpdateAuras() {
+	for _, aura := range slices.Clone(w.auras) {
+		var now []*Actor
+		for _, cell := range w.Grid.Cells(aura.candidates(w)) {
+			if aura.Contains(cell.Position) {
+				now = append(now, cell.Occupants...)
+			}
+		}
+
+		old := aura.inside
+		aura.inside = now
+		for _, actor := range old {
+			if !slices.Contains(now, actor) {
+				aura.leave(actor)
+			}
+		}
+
+		for _, actor := range now {
+			if !slices.Contains(old, actor) {
+				aura.enter(actor)
+			}
+		}
+	}
+}
+
+// evaluateAuras runs the handlers of every aura the actor stands in, after the actor's own effects
+func (w *World) evaluateAuras(actor *Actor, state any) {
+	for _, aura := range w.auras {
+		if aura.IsInside(actor) {
+			aura.Effect.Evaluate(state)
+		}
+	}
+}
+
+func (w *World) positions() []grid.Position {
+	positions := make([]grid.Position, 0, w.Width()*w.Height())
+	for y := range w.Height() {
+		for x := range w.Width() {
+			positions = append(positions, grid.Position{X: x, Y: y})
+		}
+	}
+	return positions
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+)
+
+func TestWorld_Auras(t *testing.T) {
+	setup := func() (*World, *Actor, *Actor) {
+		world := NewWorld(loader.WorldDefinition{Width: 8, Height: 8})
+		return world, newTestActor(world, "paladin", grid.Position{X: 1, Y: 1}), newTestActor(world, "ally", grid.Position{X: 2, Y: 2})
+	}
+	protection := func(source *Actor) *Aura {
+		fx := &Effect{Name: "Aura of Protection"}
+		On(fx, func(s *PreSavingThrow) { s.Expression.AddConstant(3, fx.Name) })
+		return &Aura{Name: fx.Name, Source: source, Radius: 1, Effect: fx}
+	}
+	save := func(a *Actor) int {
+		a.World.SetRoller(&sequenceRoller{values: []int{10}})
+		return a.SaveThrow(tags.AttributeWisdom, 10).Value
+	}
+
+	t.Run("applies its handlers to every actor inside", func(t *testing.T) {
+		world, paladin, ally := setup()
+		world.AddAura(protection(paladin))
+
+		assert.Equal(t, 13, save(paladin))
+		assert.Equal(t, 13, save(ally))
+	})
+
+	t.Run("follows its source", func(t *testing.T) {
+		world, paladin, ally := setup()
+		aura := protection(paladin)
+		world.AddAura(aura)
+
+		paladin.Move(grid.Position{X: 0, Y: 0}, nil)
+		assert.False(t, aura.IsInside(ally))
+		assert.Equal(t, 10, save(ally))
+
+		paladin.Move(grid.Position{X: 1, Y: 1}, nil)
+		assert.True(t, aura.IsInside(ally))
+	})
+
+	t.Run("fires enter and leave states", func(t *testing.T) {
+		world, paladin, ally := setup()
+		var entered, left []string
+		fx := &Effect{Name: "Spirit Guardians"}
+		On(fx, func(s *AuraEntered) { entered = append(entered, s.Source.Name) })
+		On(fx, func(s *AuraLeft) { left = append(left, s.Source.Name) })
+		aura := &Aura{Name: fx.Name, Source: paladin, Radius: 1, Effect: fx}
+
+		world.AddAura(aura)
+		ally.Move(grid.Position{X: 4, Y: 4}, nil)
+		ally.Move(grid.Position{X: 2, Y: 1}, nil)
+		world.RemoveAura(aura)
+
+		assert.Equal(t, []string{"paladin", "ally", "ally"}, entered)
+		assert.Equal(t, []string{"ally", "paladin", "ally"}, left)
+	})
+
+	t.Run("anchored cells stay in place", func(t *testing.T) {
+		world, paladin, ally := setup()
+		web := &Aura{Name: "Web", Source: paladin, Cells: []grid.Position{{X: 2, Y: 2}, {X: 3, Y: 2}}, Effect: &Effect{Name: "Web"}}
+		world.AddAura(web)
+
+		assert.Equal(t, []*Actor{ally}, web.Inside())
+
+		paladin.Move(grid.Position{X: 3, Y: 2}, nil)
+		assert.ElementsMatch(t, []*Actor{paladin, ally}, web.Inside())
+	})
+
+	t.Run("circles leave out the corners", func(t *testing.T) {
+		world, paladin, ally := setup()
+		aura := &Aura{Name: "Circle", Source: paladin, Shape: AuraCircle, Radius: 1, Effect: &Effect{Name: "Circle"}}
+		world.AddAura(aura)
+
+		assert.False(t, aura.IsInside(ally))
+	})
+
+	t.Run("world auras cover the whole map", func(t *testing.T) {
+		world, paladin, ally := setup()
+		lair := &Aura{Name: "Lair", Shape: AuraWorld, Effect: &Effect{Name: "Lair"}}
+		world.AddAura(lair)
+
+		ally.Move(grid.Position{X: 7, Y: 7}, nil)
+		assert.ElementsMatch(t, []*Actor{paladin, ally}, lair.Inside())
+	})
+}
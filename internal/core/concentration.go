@@ -0,0 +1,102 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/mathi"
+)
+
+type linkedEffect struct {
+	Target *Actor
+	Effect *Effect
+}
+
+// Concentration is the spell an actor keeps up and every effect it placed because of it, all of them end
+// together when concentration breaks
+type Concentration struct {
+	Spell  string
+	Source *Actor
+	effect *Effect
+	linked []linkedEffect
+}
+
+// Concentrate starts concentrating on a spell for the given duration, ending any previous concentration
+func (a *Actor) Concentrate(spell string, duration Duration) *Concentration {
+	a.BreakConcentration(fmt.Sprintf("Started concentrating on %s", spell))
+	c := &Concentration{Spell: spell, Source: a}
+	c.effect = newConcentrationEffect(c)
+	c.effect.Duration = duration
+	a.Concentration = c
+	a.Dispatcher.Emit(ConcentrationEvent{Source: a, Spell: spell})
+	a.AddEffect(c.effect)
+	return c
+}
+
+// BreakConcentration ends the current concentration and every effect linked to it
+func (a *Actor) BreakConcentration(reason string) {
+	c := a.Concentration
+	if c == nil {
+		return
+	}
+
+	a.Dispatcher.Begin(ConcentrationBrokenEvent{Source: a, Spell: c.Spell, Reason: reason})
+	defer a.Dispatcher.End()
+	a.ExpireEffect(c.effect)
+}
+
+// Link adds an effect to the target that lasts only as long as the concentration
+func (c *Concentration) Link(target *Actor, fx *Effect) {
+	if fx.Source == nil {
+		fx.Source = c.Source
+	}
+	c.linked = append(c.linked, linkedEffect{Target: target, Effect: fx})
+	target.AddEffect(fx)
+}
+
+// unlink forgets an effect that ended on its own, into a fresh slice so end can keep walking the links
+func (c *Concentration) unlink(fx *Effect) {
+	c.linked = slices.DeleteFunc(slices.Clone(c.linked), func(l linkedEffect) bool { return l.Effect == fx })
+}
+
+func (c *Concentration) end() {
+	if c.Source.Concentration == c {
+		c.Source.Concentration = nil
+	}
+
+	for _, l := range c.linked {
+		l.Target.ExpireEffect(l.Effect)
+	}
+	c.linked = nil
+}
+
+func newConcentrationEffect(c *Concentration) *Effect {
+	fx := &Effect{Name: fmt.Sprintf("Concentration (%s)", c.Spell), Priority: PriorityLast}
+
+	On(fx, func(s *PostTakeDamage) {
+		damage := s.ActualDamage + s.TemporaryDamage
+		if damage == 0 || s.Source.IsDead() {
+			return
+		}
+
+		dc := mathi.Min(30, mathi.Max(10, damage/2))
+		if !s.Source.SaveThrow(tags.AttributeConstitution, dc).Success {
+			s.Source.BreakConcentration(fmt.Sprintf("Failed a DC %d Constitution save", dc))
+		}
+	})
+
+	On(fx, func(s *ConditionChanged) {
+		if s.Source.MatchCondition(tags.Incapacitated) {
+			s.Source.BreakConcentration("Became incapacitated")
+		}
+	})
+
+	On(fx, func(s *EffectExpired) {
+		if s.Effect == fx {
+			c.end()
+		}
+	})
+
+	return fx
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/expression"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+	"anvil/internal/tag"
+)
+
+func TestActor_Concentration(t *testing.T) {
+	setup := func(rolls ...int) (*Actor, *Actor) {
+		world := NewWorld(loader.WorldDefinition{Width: 3, Height: 3})
+		world.SetRoller(&sequenceRoller{values: rolls})
+		return newTestActor(world, "caster", grid.Position{}), newTestActor(world, "target", grid.Position{X: 1})
+	}
+	damage := func(amount int) expression.Expression {
+		return *expression.FromDamageConstant(amount, tag.ContainerFromTag(tags.Fire), "test").EvaluateDamage()
+	}
+
+	t.Run("links effects on other actors", func(t *testing.T) {
+		caster, target := setup()
+		bless := &Effect{Name: "Bless"}
+		caster.Concentrate("Bless", ForRounds(10)).Link(target, bless)
+
+		assert.Contains(t, target.Effects.effects, bless)
+		assert.Equal(t, "Bless", caster.Concentration.Spell)
+	})
+
+	t.Run("a new concentration ends the previous one", func(t *testing.T) {
+		caster, target := setup()
+		bless := &Effect{Name: "Bless"}
+		caster.Concentrate("Bless", ForRounds(10)).Link(target, bless)
+		caster.Concentrate("Hold Person", ForRounds(10))
+
+		assert.NotContains(t, target.Effects.effects, bless)
+		assert.Equal(t, "Hold Person", caster.Concentration.Spell)
+		assert.Len(t, caster.Effects.effects, 1)
+	})
+
+	t.Run("failing the constitution save breaks concentration", func(t *testing.T) {
+		caster, target := setup(9)
+		mark := &Effect{Name: "Hunter's Mark"}
+		caster.Concentrate("Hunter's Mark", ForRounds(10)).Link(target, mark)
+		var dc int
+		spy := &Effect{Name: "spy"}
+		On(spy, func(s *PreSavingThrow) { dc = s.DifficultyClass })
+		caster.AddEffect(spy)
+
+		caster.TakeDamage(damage(4))
+
+		assert.Equal(t, 10, dc)
+		assert.Nil(t, caster.Concentration)
+		assert.NotContains(t, target.Effects.effects, mark)
+	})
+
+	t.Run("the save is half the damage when higher", func(t *testing.T) {
+		caster, _ := setup(11)
+		caster.HitPoints = 30
+		caster.Concentrate("Web", ForRounds(10))
+
+		caster.TakeDamage(damage(24))
+
+		assert.Nil(t, caster.Concentration)
+	})
+
+	t.Run("the save is never above DC 30", func(t *testing.T) {
+		caster, _ := setup(9)
+		caster.HitPoints = 100
+		caster.Concentrate("Web", ForRounds(10))
+		var dc int
+		spy := &Effect{Name: "spy"}
+		On(spy, func(s *PreSavingThrow) { dc = s.DifficultyClass })
+		caster.AddEffect(spy)
+
+		caster.TakeDamage(damage(80))
+
+		assert.Equal(t, 30, dc)
+	})
+
+	t.Run("passing the save keeps concentration", func(t *testing.T) {
+		caster, target := setup(10)
+		mark := &Effect{Name: "Hunter's Mark"}
+		caster.Concentrate("Hunter's Mark", ForRounds(10)).Link(target, mark)
+
+		caster.TakeDamage(damage(4))
+
+		assert.NotNil(t, caster.Concentration)
+		assert.Contains(t, target.Effects.effects, mark)
+	})
+
+	t.Run("becoming incapacitated breaks concentration", func(t *testing.T) {
+		caster, target := setup()
+		web := &Effect{Name: "Web"}
+		caster.Concentrate("Web", ForRounds(10)).Link(target, web)
+
+		caster.AddCondition(tags.Unconscious, &Effect{Name: "Sleep"})
+
+		assert.Nil(t, caster.Concentration)
+		assert.NotContains(t, target.Effects.effects, web)
+	})
+
+	t.Run("linked effects end when the concentration expires", func(t *testing.T) {
+		caster, target := setup()
+		bless := &Effect{Name: "Bless"}
+		caster.Concentrate("Bless", UntilTriggered()).Link(target, bless)
+
+		caster.ExpireEffect(caster.Concentration.effect)
+
+		assert.Nil(t, caster.Concentration)
+		assert.NotContains(t, target.Effects.effects, bless)
+	})
+
+	t.Run("a linked effect replaced by a stronger one expires and is unlinked", func(t *testing.T) {
+		caster, target := setup()
+		expired := false
+		weak := &Effect{Archetype: "aid", Stacking: StackingStrongest, Strength: 5}
+		On(weak, func(s *EffectExpired) { expired = s.Effect == weak })
+		concentration := caster.Concentrate("Aid", ForRounds(10))
+		concentration.Link(target, weak)
+
+		target.AddEffect(&Effect{Archetype: "aid", Stacking: StackingStrongest, Strength: 10})
+
+		assert.True(t, expired)
+		assert.NotContains(t, target.Effects.effects, weak)
+		assert.Empty(t, concentration.linked)
+	})
+}
@@ -8,12 +8,17 @@ import (
 
 type Conditions struct {
 	Sources map[tag.Tag][]*Effect
+	// Levels of leveled conditions such as exhaustion, present while above 0
+	Levels map[tag.Tag]int
 }
 
 func (c *Conditions) init() {
 	if c.Sources == nil {
 		c.Sources = make(map[tag.Tag][]*Effect)
 	}
+	if c.Levels == nil {
+		c.Levels = make(map[tag.Tag]int)
+	}
 }
 
 func (c *Conditions) Has(t tag.Tag, src *Effect) bool {
@@ -25,8 +30,8 @@ func (c *Conditions) Has(t tag.Tag, src *Effect) bool {
 }
 
 func (c *Conditions) Match(t tag.Tag) bool {
-	for tag := range c.Sources {
-		if tag.Match(t) {
+	for tag, sources := range c.Sources {
+		if len(sources) > 0 && tag.Match(t) {
 			return true
 		}
 	}
@@ -62,3 +67,25 @@ func (c *Conditions) removeAll(t tag.Tag) bool {
 	after := len(c.Sources[t])
 	return after < before
 }
+
+// ExhaustionMaxLevel is the exhaustion level that kills, levels never rise past it
+const ExhaustionMaxLevel = 6
+
+func (c *Conditions) Level(t tag.Tag) int {
+	return c.Levels[t]
+}
+
+// SetLevel adds the condition from src when it rises above 0 and removes it from every source at 0
+func (c *Conditions) SetLevel(t tag.Tag, level int, src *Effect) {
+	c.init()
+	if level <= 0 {
+		delete(c.Levels, t)
+		c.removeAll(t)
+		return
+	}
+
+	if !c.Has(t, nil) {
+		c.Add(t, src)
+	}
+	c.Levels[t] = level
+}
@@ -66,3 +66,30 @@ func TestConditions_Has(t *testing.T) {
 	effect2 := &Effect{Name: "test2"}
 	assert.False(t, c.Has(testTag, effect2), "Expected Has to return false for non-existing effect")
 }
+
+func TestConditions_Match(t *testing.T) {
+	c := &Conditions{}
+	parent := tag.FromString("parent")
+	child := tag.FromString("parent.child")
+	effect := &Effect{Name: "test"}
+
+	c.Add(child, effect)
+	assert.True(t, c.Match(parent), "Expected Match to find the child condition")
+
+	c.Remove(child, effect)
+	assert.False(t, c.Match(parent), "Expected Match to ignore removed conditions")
+}
+
+func TestConditions_Levels(t *testing.T) {
+	c := &Conditions{}
+	testTag := tag.FromString("test")
+	effect := &Effect{Name: "test"}
+
+	c.SetLevel(testTag, 2, effect)
+	assert.Equal(t, 2, c.Level(testTag), "Expected level to be set")
+	assert.True(t, c.Has(testTag, effect), "Expected a leveled condition to be present")
+
+	c.SetLevel(testTag, 0, effect)
+	assert.Equal(t, 0, c.Level(testTag), "Expected level to be cleared")
+	assert.False(t, c.Has(testTag, nil), "Expected condition to be removed at level 0")
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"fmt"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/expression"
+	"anvil/internal/tag"
+)
+
+type DamageTrait struct {
+	Kind   tag.Tag
+	Source string
+}
+
+type DamageTraits struct {
+	Resistances     []DamageTrait
+	Vulnerabilities []DamageTrait
+	Immunities      []DamageTrait
+}
+
+func NewDamageTraitsFromDefinition(resistances, vulnerabilities, immunities []string) DamageTraits {
+	traits := DamageTraits{}
+	for _, kind := range resistances {
+		traits.AddResistance(tags.DamageKindFromString(kind), "Base")
+	}
+
+	for _, kind := range vulnerabilities {
+		traits.AddVulnerability(tags.DamageKindFromString(kind), "Base")
+	}
+
+	for _, kind := range immunities {
+		traits.AddImmunity(tags.DamageKindFromString(kind), "Base")
+	}
+
+	return traits
+}
+
+func (d *DamageTraits) AddResistance(kind tag.Tag, source string) {
+	d.Resistances = append(d.Resistances, DamageTrait{Kind: kind, Source: source})
+}
+
+func (d *DamageTraits) AddVulnerability(kind tag.Tag, source string) {
+	d.Vulnerabilities = append(d.Vulnerabilities, DamageTrait{Kind: kind, Source: source})
+}
+
+func (d *DamageTraits) AddImmunity(kind tag.Tag, source string) {
+	d.Immunities = append(d.Immunities, DamageTrait{Kind: kind, Source: source})
+}
+
+func (d DamageTraits) Clone() DamageTraits {
+	return DamageTraits{
+		Resistances:     append([]DamageTrait{}, d.Resistances...),
+		Vulnerabilities: append([]DamageTrait{}, d.Vulnerabilities...),
+		Immunities:      append([]DamageTrait{}, d.Immunities...),
+	}
+}
+
+func (d DamageTraits) IsResistant(damage tag.Container) bool {
+	_, ok := findDamageTrait(d.Resistances, damage)
+	return ok
+}
+
+func (d DamageTraits) IsVulnerable(damage tag.Container) bool {
+	_, ok := findDamageTrait(d.Vulnerabilities, damage)
+	return ok
+}
+
+func (d DamageTraits) IsImmune(damage tag.Container) bool {
+	_, ok := findDamageTrait(d.Immunities, damage)
+	return ok
+}
+
+// Apply adjusts every typed damage group of an evaluated damage expression, resistance first and vulnerability
+// after, recording each step as its own component so the result can be audited
+func (d DamageTraits) Apply(damage *expression.Expression) *expression.Expression {
+	result := &expression.Expression{Rng: damage.Rng}
+	for _, group := range damage.Components {
+		result.Components = append(result.Components, group)
+		groupTags := group.Tags()
+		value := group.Value()
+		if value < 0 {
+			result.AddDamageConstant(-value, groupTags.Clone(), "Minimum Damage")
+			continue
+		}
+
+		if trait, ok := findDamageTrait(d.Immunities, groupTags); ok {
+			result.AddDamageConstant(-value, groupTags.Clone(), traitSource("Immunity", trait))
+			continue
+		}
+
+		if trait, ok := findDamageTrait(d.Resistances, groupTags); ok {
+			result.AddDamageConstant(value/2-value, groupTags.Clone(), traitSource("Resistance", trait))
+			value /= 2
+		}
+
+		if trait, ok := findDamageTrait(d.Vulnerabilities, groupTags); ok {
+			result.AddDamageConstant(value, groupTags.Clone(), traitSource("Vulnerability", trait))
+		}
+	}
+
+	return result.Evaluate()
+}
+
+func findDamageTrait(traits []DamageTrait, damage tag.Container) (DamageTrait, bool) {
+	for _, trait := range traits {
+		if damage.MatchTag(trait.Kind) {
+			return trait, true
+		}
+	}
+
+	return DamageTrait{}, false
+}
+
+func traitSource(name string, trait DamageTrait) string {
+	return fmt.Sprintf("%s: %s (%s)", name, tags.ToReadable(trait.Kind), trait.Source)
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/expression"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+	"anvil/internal/tag"
+)
+
+func damageOf(value int, kind tag.Tag) *expression.Expression {
+	return expression.FromDamageConstant(value, tag.ContainerFromTag(kind), "test").EvaluateDamage()
+}
+
+func TestDamageTraits_Apply(t *testing.T) {
+	t.Run("resistance halves rounding down", func(t *testing.T) {
+		traits := DamageTraits{}
+		traits.AddResistance(tags.Fire, "Base")
+
+		result := traits.Apply(damageOf(7, tags.Fire))
+
+		assert.Equal(t, 3, result.Value)
+		assert.Equal(t, "Resistance: Fire (Base)", result.Components[1].Source())
+	})
+
+	t.Run("vulnerability doubles", func(t *testing.T) {
+		traits := DamageTraits{}
+		traits.AddVulnerability(tags.Fire, "Base")
+
+		assert.Equal(t, 14, traits.Apply(damageOf(7, tags.Fire)).Value)
+	})
+
+	t.Run("resistance applies before vulnerability", func(t *testing.T) {
+		traits := DamageTraits{}
+		traits.AddResistance(tags.Fire, "Base")
+		traits.AddVulnerability(tags.Fire, "Base")
+
+		result := traits.Apply(damageOf(7, tags.Fire))
+
+		assert.Equal(t, 6, result.Value)
+		assert.Len(t, result.Components, 3)
+	})
+
+	t.Run("immunity zeroes the group", func(t *testing.T) {
+		traits := DamageTraits{}
+		traits.AddImmunity(tags.Poison, "Base")
+		traits.AddVulnerability(tags.Poison, "Base")
+
+		assert.Equal(t, 0, traits.Apply(damageOf(7, tags.Poison)).Value)
+	})
+
+	t.Run("only matching groups are adjusted", func(t *testing.T) {
+		traits := DamageTraits{}
+		traits.AddResistance(tags.Fire, "Base")
+		damage := expression.FromDamageConstant(8, tag.ContainerFromTag(tags.Slashing), "sword")
+		damage.AddDamageConstant(5, tag.ContainerFromTag(tags.Fire), "flame tongue")
+
+		assert.Equal(t, 10, traits.Apply(damage.EvaluateDamage()).Value)
+	})
+
+	t.Run("flat modifiers apply before resistance", func(t *testing.T) {
+		traits := DamageTraits{}
+		traits.AddResistance(tags.Slashing, "Base")
+		damage := expression.FromDamageConstant(9, tag.ContainerFromTag(tags.Slashing), "sword")
+		damage.AddConstant(-3, "Heavy Armor Master")
+
+		assert.Equal(t, 3, traits.Apply(damage.EvaluateDamage()).Value)
+	})
+
+	t.Run("flat modifiers never turn damage into healing", func(t *testing.T) {
+		damage := expression.FromDamageConstant(2, tag.ContainerFromTag(tags.Slashing), "dagger")
+		damage.AddConstant(-3, "Heavy Armor Master")
+
+		assert.Equal(t, 0, DamageTraits{}.Apply(damage.EvaluateDamage()).Value)
+	})
+}
+
+func TestActor_TakeDamage_Traits(t *testing.T) {
+	t.Run("uses traits from the definition", func(t *testing.T) {
+		actor := newTestActor(NewWorld(loader.WorldDefinition{Width: 3, Height: 3}), "actor", grid.Position{})
+		actor.DamageTraits = NewDamageTraitsFromDefinition(nil, nil, []string{"poison"})
+
+		actor.TakeDamage(*damageOf(5, tags.Poison))
+
+		assert.Equal(t, 10, actor.HitPoints)
+	})
+
+	t.Run("effects can grant traits", func(t *testing.T) {
+		actor := newTestActor(NewWorld(loader.WorldDefinition{Width: 3, Height: 3}), "actor", grid.Position{})
+		fx := &Effect{Name: "Stoneskin"}
+		On(fx, func(s *DamageTraitsCalculation) { s.Traits.AddResistance(tags.Bludgeoning, fx.Name) })
+		actor.AddEffect(fx)
+
+		actor.TakeDamage(*damageOf(5, tags.Bludgeoning))
+
+		assert.Equal(t, 8, actor.HitPoints)
+		assert.True(t, actor.EffectiveDamageTraits().IsResistant(tag.ContainerFromTag(tags.Bludgeoning)))
+	})
+}
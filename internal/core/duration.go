@@ -0,0 +1,100 @@
+package core
+
+import "slices"
+
+type DurationKind string
+
+const (
+	DurationPermanent      DurationKind = ""
+	DurationRounds         DurationKind = "rounds"
+	DurationTurnStart      DurationKind = "turn-start"
+	DurationTurnEnd        DurationKind = "turn-end"
+	DurationUntilTriggered DurationKind = "until-triggered"
+)
+
+// Duration is how long an effect lasts once added to an actor, turn durations count the turns of the
+// anchor which defaults to the actor bearing the effect
+type Duration struct {
+	Kind   DurationKind
+	Count  int
+	Anchor *Actor
+}
+
+// ForRounds lasts until the given number of new rounds have started
+func ForRounds(rounds int) Duration {
+	return Duration{Kind: DurationRounds, Count: rounds}
+}
+
+// UntilStartOfTurn lasts until the anchor has started the given number of turns, 1 is the start of its next turn
+func UntilStartOfTurn(anchor *Actor, turns int) Duration {
+	return Duration{Kind: DurationTurnStart, Count: turns, Anchor: anchor}
+}
+
+// UntilEndOfTurn lasts until the anchor has ended the given number of turns, the turn it is taking when
+// the effect is added does not count so 0 is the end of the current turn and 1 the end of its next turn
+func UntilEndOfTurn(anchor *Actor, turns int) Duration {
+	return Duration{Kind: DurationTurnEnd, Count: turns, Anchor: anchor}
+}
+
+// UntilTriggered lasts until the effect calls Actor.ExpireEffect on itself
+func UntilTriggered() Duration {
+	return Duration{Kind: DurationUntilTriggered}
+}
+
+func (d Duration) anchorOr(bearer *Actor) *Actor {
+	if d.Anchor == nil {
+		return bearer
+	}
+	return d.Anchor
+}
+
+func (e *Effect) startDuration(bearer *Actor) {
+	e.remaining = e.Duration.Count
+	if e.Duration.Kind == DurationTurnEnd && e.Duration.anchorOr(bearer).isTakingTurn() {
+		e.remaining++
+	}
+}
+
+// tickDurations counts a boundary down on every effect that lasts until it and expires those that ran out
+func (a *Actor) tickDurations(kind DurationKind, anchor *Actor) {
+	var expired []*Effect
+	for _, fx := range a.Effects.effects {
+		if fx.Duration.Kind != kind || (kind != DurationRounds && fx.Duration.anchorOr(a) != anchor) {
+			continue
+		}
+
+		fx.remaining--
+		if fx.remaining <= 0 {
+			expired = append(expired, fx)
+		}
+	}
+
+	for _, fx := range expired {
+		a.ExpireEffect(fx)
+	}
+}
+
+// ExpireEffect lets an effect clean up after itself through EffectExpired and removes it
+func (a *Actor) ExpireEffect(fx *Effect) {
+	if !a.Effects.Contains(fx) {
+		return
+	}
+
+	fx.Evaluate(&EffectExpired{Source: a, Effect: fx})
+	a.Effects.Remove(fx)
+	if fx.Source != nil && fx.Source.Concentration != nil {
+		fx.Source.Concentration.unlink(fx)
+	}
+	a.Dispatcher.Emit(EffectExpiredEvent{Source: a, Effect: fx})
+}
+
+func (a *Actor) isTakingTurn() bool {
+	e := a.Encounter
+	return e != nil && e.Turn < len(e.InitiativeOrder) && e.ActiveActor() == a
+}
+
+func (e *Encounter) tickDurations(kind DurationKind, anchor *Actor) {
+	for _, a := range slices.Clone(e.Actors) {
+		a.tickDurations(kind, anchor)
+	}
+}
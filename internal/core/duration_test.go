@@ -0,0 +1,120 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/eventbus"
+)
+
+func TestEffect_Duration(t *testing.T) {
+	// Turns go A, B, C every round
+	newDurationEncounter := func() *Encounter {
+		encounter := newTestEncounter([]int{18, 12, 6}, 10, 10, 10)
+		encounter.Start()
+		return encounter
+	}
+	hasEffect := func(a *Actor, fx *Effect) bool {
+		for _, e := range a.Effects.effects {
+			if e == fx {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("permanent effects never expire", func(t *testing.T) {
+		encounter := newDurationEncounter()
+		fx := &Effect{Name: "Permanent"}
+		encounter.Actors[1].AddEffect(fx)
+
+		for range 6 {
+			encounter.EndTurn()
+		}
+		assert.True(t, hasEffect(encounter.Actors[1], fx))
+	})
+
+	t.Run("rounds expire when enough new rounds started", func(t *testing.T) {
+		encounter := newDurationEncounter()
+		fx := &Effect{Name: "Bless", Duration: ForRounds(2)}
+		encounter.Actors[1].AddEffect(fx)
+
+		for range 3 {
+			encounter.EndTurn()
+		}
+		assert.True(t, hasEffect(encounter.Actors[1], fx))
+
+		for range 3 {
+			encounter.EndTurn()
+		}
+		assert.False(t, hasEffect(encounter.Actors[1], fx))
+	})
+
+	t.Run("until the start of the anchor's next turn", func(t *testing.T) {
+		encounter := newDurationEncounter()
+		caster := encounter.ActiveActor()
+		fx := &Effect{Name: "Dodge", Duration: UntilStartOfTurn(caster, 1)}
+		caster.AddEffect(fx)
+
+		encounter.EndTurn()
+		encounter.EndTurn()
+		assert.True(t, hasEffect(caster, fx))
+
+		encounter.EndTurn()
+		assert.False(t, hasEffect(caster, fx))
+	})
+
+	t.Run("until the end of the anchor's next turn skips the current turn", func(t *testing.T) {
+		encounter := newDurationEncounter()
+		caster, target := encounter.Actors[0], encounter.Actors[1]
+		fx := &Effect{Name: "Vex", Duration: UntilEndOfTurn(caster, 1)}
+		target.AddEffect(fx)
+
+		encounter.EndTurn()
+		assert.True(t, hasEffect(target, fx))
+
+		encounter.EndTurn()
+		encounter.EndTurn()
+		assert.True(t, hasEffect(target, fx))
+
+		encounter.EndTurn()
+		assert.False(t, hasEffect(target, fx))
+	})
+
+	t.Run("until the end of the current turn", func(t *testing.T) {
+		encounter := newDurationEncounter()
+		fx := &Effect{Name: "Surge", Duration: UntilEndOfTurn(nil, 0)}
+		encounter.ActiveActor().AddEffect(fx)
+
+		encounter.EndTurn()
+		assert.False(t, hasEffect(encounter.Actors[0], fx))
+	})
+
+	t.Run("until triggered expires on demand and cleans up", func(t *testing.T) {
+		encounter := newDurationEncounter()
+		bearer := encounter.Actors[2]
+		var events []EffectExpiredEvent
+		encounter.Dispatcher.(*eventbus.Dispatcher).Subscribe(eventbus.EventType(EffectExpiredEvent{}), func(e eventbus.Event) {
+			if e.End {
+				events = append(events, e.Data.(EffectExpiredEvent))
+			}
+		})
+
+		cleaned := false
+		fx := &Effect{Name: "Hunter's Mark", Duration: UntilTriggered()}
+		On(fx, func(s *EffectExpired) { cleaned = true })
+		On(fx, func(s *TurnStarted) { s.Source.ExpireEffect(fx) })
+		other := &Effect{Name: "Other"}
+		bearer.AddEffect(fx, other)
+
+		encounter.EndTurn()
+		assert.True(t, hasEffect(bearer, fx))
+
+		encounter.EndTurn()
+		assert.False(t, hasEffect(bearer, fx))
+		assert.True(t, hasEffect(bearer, other))
+		assert.True(t, cleaned)
+		assert.Equal(t, []EffectExpiredEvent{{Source: bearer, Effect: fx}}, events)
+	})
+}
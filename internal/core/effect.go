@@ -1,9 +1,6 @@
 package core
 
-import (
-	"reflect"
-	"sync"
-)
+import "reflect"
 
 type Priority int
 
@@ -16,14 +13,8 @@ const (
 	PriorityLast         Priority = 40
 )
 
-type Handlers map[string]func(*Effect, any)
-
-func (h *Handlers) get() Handlers {
-	if *h == nil {
-		*h = make(map[string]func(*Effect, any))
-	}
-	return *h
-}
+// Handlers maps a state type to the handler registered for it with On
+type Handlers map[reflect.Type]func(any)
 
 type Effect struct {
 	Archetype string
@@ -31,6 +22,13 @@ type Effect struct {
 	Name      string
 	Handlers  Handlers
 	Priority  Priority
+	Duration  Duration
+	Stacking  StackingPolicy
+	// Strength ranks instances of an archetype for StackingStrongest
+	Strength int
+	// Source is the actor that placed the effect, nil for an actor's own features
+	Source    *Actor
+	remaining int
 }
 
 func (e *Effect) Evaluate(state any) {
@@ -39,45 +37,16 @@ func (e *Effect) Evaluate(state any) {
 		panic("state must be a pointer")
 	}
 
-	eventName := stateType.Elem().Name()
-
-	handler, exists := e.Handlers.get()[eventName]
-	if !exists {
-		return
+	if handler, exists := e.Handlers[stateType]; exists {
+		handler(state)
 	}
-
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		handler(e, state)
-	}()
-	wg.Wait()
 }
 
-func (e *Effect) withHandler(event string, handler func(*Effect, any)) {
-	e.Handlers.get()[event] = handler
-}
-
-// On registers a handler using reflection to derive the event name from the state type
-func (e *Effect) On(handler any) {
-	handlerType := reflect.TypeOf(handler)
-	if handlerType.Kind() != reflect.Func {
-		panic("handler must be a function")
-	}
-
-	if handlerType.NumIn() != 1 {
-		panic("handler must have exactly one parameter")
+// On registers the handler for a state type, a later handler for the same type replaces the earlier one
+func On[T any](e *Effect, handler func(*T)) {
+	if e.Handlers == nil {
+		e.Handlers = make(Handlers)
 	}
 
-	paramType := handlerType.In(0)
-	if paramType.Kind() != reflect.Ptr {
-		panic("handler parameter must be a pointer")
-	}
-
-	eventName := paramType.Elem().Name()
-	handlerValue := reflect.ValueOf(handler)
-	e.Handlers.get()[eventName] = func(_ *Effect, state any) {
-		handlerValue.Call([]reflect.Value{reflect.ValueOf(state)})
-	}
+	e.Handlers[reflect.TypeFor[*T]()] = func(state any) { handler(state.(*T)) }
 }
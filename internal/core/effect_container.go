@@ -1,25 +1,151 @@
 package core
 
-import "slices"
+import (
+	"slices"
 
+	"github.com/google/uuid"
+)
+
+type StackingPolicy string
+
+const (
+	// StackingStack applies every instance of an archetype
+	StackingStack StackingPolicy = ""
+	// StackingRefresh keeps the first instance and restarts its duration with the newer one
+	StackingRefresh StackingPolicy = "refresh"
+	// StackingStrongest keeps only the instance with the highest Strength
+	StackingStrongest StackingPolicy = "strongest"
+	// StackingUniquePerSource keeps one instance per source, the newer one replaces the older
+	StackingUniquePerSource StackingPolicy = "unique-per-source"
+)
+
+// EffectContainer keeps effects in priority order and keys them by ID, effects without one get an ID on Add
 type EffectContainer struct {
 	effects []*Effect
+	byID    map[string]*Effect
 }
 
 func (c *EffectContainer) Add(effect ...*Effect) {
-	c.effects = append(c.effects, effect...)
-	slices.SortFunc(c.effects, func(a, b *Effect) int {
+	for _, fx := range effect {
+		c.add(fx)
+	}
+}
+
+func (c *EffectContainer) add(fx *Effect) {
+	if fx.ID == "" {
+		fx.ID = uuid.New().String()
+	}
+
+	if _, ok := c.byID[fx.ID]; ok {
+		return
+	}
+
+	if fx.Archetype != "" && fx.Stacking != StackingStack {
+		for _, existing := range c.effects {
+			if existing.Archetype != fx.Archetype {
+				continue
+			}
+
+			switch fx.Stacking {
+			case StackingRefresh:
+				existing.Duration = fx.Duration
+				existing.remaining = fx.remaining
+				return
+			case StackingStrongest:
+				if fx.Strength <= existing.Strength {
+					return
+				}
+			}
+		}
+
+		for _, existing := range c.Replaced(fx) {
+			c.Remove(existing)
+		}
+	}
+
+	if c.byID == nil {
+		c.byID = make(map[string]*Effect)
+	}
+	c.byID[fx.ID] = fx
+	effects := append(slices.Clone(c.effects), fx)
+	slices.SortStableFunc(effects, func(a, b *Effect) int {
 		return int(a.Priority) - int(b.Priority)
 	})
+	c.effects = effects
+}
+
+// Replaced lists the effects that adding fx takes the place of under its stacking policy
+func (c *EffectContainer) Replaced(fx *Effect) []*Effect {
+	if fx.Archetype == "" {
+		return nil
+	}
+
+	return c.filter(func(e *Effect) bool {
+		if e.Archetype != fx.Archetype || e.ID == fx.ID {
+			return false
+		}
+
+		switch fx.Stacking {
+		case StackingStrongest:
+			return fx.Strength > e.Strength
+		case StackingUniquePerSource:
+			return e.Source == fx.Source
+		default:
+			return false
+		}
+	})
 }
 
+// Remove drops the effect with the same ID into a fresh slice so an evaluation in progress is not disturbed
 func (c *EffectContainer) Remove(effect *Effect) {
-	for i, e := range c.effects {
-		if e.Name == effect.Name {
-			c.effects = append(c.effects[:i], c.effects[i+1:]...)
-			return
+	if _, ok := c.byID[effect.ID]; !ok {
+		return
+	}
+
+	delete(c.byID, effect.ID)
+	c.effects = slices.DeleteFunc(slices.Clone(c.effects), func(e *Effect) bool { return e.ID == effect.ID })
+}
+
+func (c *EffectContainer) RemoveAll(archetype string) {
+	for _, fx := range c.ByArchetype(archetype) {
+		c.Remove(fx)
+	}
+}
+
+func (c *EffectContainer) Get(id string) (*Effect, bool) {
+	fx, ok := c.byID[id]
+	return fx, ok
+}
+
+func (c *EffectContainer) Contains(effect *Effect) bool {
+	fx, ok := c.byID[effect.ID]
+	return ok && fx == effect
+}
+
+func (c *EffectContainer) Has(archetype string) bool {
+	return slices.ContainsFunc(c.effects, func(e *Effect) bool { return e.Archetype == archetype })
+}
+
+func (c *EffectContainer) ByArchetype(archetype string) []*Effect {
+	return c.filter(func(e *Effect) bool { return e.Archetype == archetype })
+}
+
+func (c *EffectContainer) BySource(source *Actor) []*Effect {
+	return c.filter(func(e *Effect) bool { return e.Source == source })
+}
+
+func (c *EffectContainer) All() []*Effect {
+	return c.effects
+}
+
+func (c *EffectContainer) filter(keep func(*Effect) bool) []*Effect {
+	var result []*Effect
+	for _, fx := range c.effects {
+		if keep(fx) {
+			result = append(result, fx)
 		}
 	}
+	return result
 }
 
 func (c *EffectContainer) Evaluate(state any) {
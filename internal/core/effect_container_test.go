@@ -25,7 +25,7 @@ func TestContainer_Add(t *testing.T) {
 }
 
 func TestContainer_Remove(t *testing.T) {
-	t.Run("removes effect by name", func(t *testing.T) {
+	t.Run("removes effect by id", func(t *testing.T) {
 		c := &EffectContainer{}
 		e1 := &Effect{Name: "e1"}
 		e2 := &Effect{Name: "e2"}
@@ -37,6 +37,17 @@ func TestContainer_Remove(t *testing.T) {
 		assert.Equal(t, []*Effect{e2}, c.effects)
 	})
 
+	t.Run("tells effects with the same name apart", func(t *testing.T) {
+		c := &EffectContainer{}
+		first := &Effect{Name: "Bless"}
+		second := &Effect{Name: "Bless"}
+
+		c.Add(first, second)
+		c.Remove(second)
+
+		assert.Equal(t, []*Effect{first}, c.effects)
+	})
+
 	t.Run("does nothing if effect not found", func(t *testing.T) {
 		c := &EffectContainer{}
 		e1 := &Effect{Name: "e1"}
@@ -55,11 +66,11 @@ func TestContainer_Evaluate(t *testing.T) {
 		c := &EffectContainer{}
 		order := []string{}
 		e1 := &Effect{Name: "e1", Priority: PriorityNormal}
-		e1.withHandler("TestContainer", func(_ *Effect, _ any) { order = append(order, "e1") })
+		On(e1, func(_ *TestContainer) { order = append(order, "e1") })
 		e2 := &Effect{Name: "e2", Priority: PriorityEarly}
-		e2.withHandler("TestContainer", func(_ *Effect, _ any) { order = append(order, "e2") })
+		On(e2, func(_ *TestContainer) { order = append(order, "e2") })
 		e3 := &Effect{Name: "e3", Priority: PriorityLate}
-		e3.withHandler("TestContainer", func(_ *Effect, _ any) { order = append(order, "e3") })
+		On(e3, func(_ *TestContainer) { order = append(order, "e3") })
 
 		c.Add(e1)
 		c.Add(e2)
@@ -72,9 +83,97 @@ func TestContainer_Evaluate(t *testing.T) {
 	t.Run("does nothing for unhandled events", func(t *testing.T) {
 		c := &EffectContainer{}
 		e1 := &Effect{Name: "e1"}
-		e1.withHandler("TestContainer", func(_ *Effect, _ any) { assert.Fail(t, "should not be called") })
+		On(e1, func(_ *TestContainer) { assert.Fail(t, "should not be called") })
 
 		c.Add(e1)
 		c.Evaluate(&UnhandledEvent{})
 	})
 }
+
+func TestContainer_Stacking(t *testing.T) {
+	cleric, paladin := &Actor{Name: "cleric"}, &Actor{Name: "paladin"}
+
+	t.Run("stacks by default", func(t *testing.T) {
+		c := &EffectContainer{}
+		c.Add(&Effect{Archetype: "bless"}, &Effect{Archetype: "bless"})
+
+		assert.Len(t, c.ByArchetype("bless"), 2)
+	})
+
+	t.Run("ignores the same effect added twice", func(t *testing.T) {
+		c := &EffectContainer{}
+		fx := &Effect{Archetype: "bless"}
+		c.Add(fx, fx)
+
+		assert.Len(t, c.effects, 1)
+	})
+
+	t.Run("refresh restarts the existing duration", func(t *testing.T) {
+		c := &EffectContainer{}
+		first := &Effect{Archetype: "bless", Stacking: StackingRefresh, Duration: ForRounds(10), remaining: 2}
+		second := &Effect{Archetype: "bless", Stacking: StackingRefresh, Duration: ForRounds(10), remaining: 10}
+		c.Add(first, second)
+
+		assert.Equal(t, []*Effect{first}, c.effects)
+		assert.Equal(t, 10, first.remaining)
+	})
+
+	t.Run("keeps the strongest", func(t *testing.T) {
+		c := &EffectContainer{}
+		weak := &Effect{Archetype: "aid", Stacking: StackingStrongest, Strength: 5}
+		strong := &Effect{Archetype: "aid", Stacking: StackingStrongest, Strength: 10}
+		weaker := &Effect{Archetype: "aid", Stacking: StackingStrongest, Strength: 3}
+		c.Add(weak, strong, weaker)
+
+		assert.Equal(t, []*Effect{strong}, c.effects)
+	})
+
+	t.Run("unique per source replaces the source's older instance", func(t *testing.T) {
+		c := &EffectContainer{}
+		first := &Effect{Archetype: "hunters-mark", Stacking: StackingUniquePerSource, Source: cleric}
+		other := &Effect{Archetype: "hunters-mark", Stacking: StackingUniquePerSource, Source: paladin}
+		second := &Effect{Archetype: "hunters-mark", Stacking: StackingUniquePerSource, Source: cleric}
+		c.Add(first, other, second)
+
+		assert.Equal(t, []*Effect{other, second}, c.effects)
+	})
+}
+
+func TestContainer_Lookup(t *testing.T) {
+	cleric := &Actor{Name: "cleric"}
+	c := &EffectContainer{}
+	bless := &Effect{Archetype: "bless", Source: cleric}
+	otherBless := &Effect{Archetype: "bless"}
+	rage := &Effect{Archetype: "rage"}
+	c.Add(bless, otherBless, rage)
+
+	assert.True(t, c.Has("bless"))
+	assert.False(t, c.Has("bane"))
+	assert.Equal(t, []*Effect{bless}, c.BySource(cleric))
+
+	found, ok := c.Get(rage.ID)
+	assert.True(t, ok)
+	assert.Equal(t, rage, found)
+
+	c.RemoveAll("bless")
+	assert.Equal(t, []*Effect{rage}, c.All())
+	_, ok = c.Get(bless.ID)
+	assert.False(t, ok)
+}
+
+func BenchmarkContainer_Evaluate(b *testing.B) {
+	c := &EffectContainer{}
+	for i := range 20 {
+		fx := &Effect{Priority: Priority(i)}
+		if i%4 == 0 {
+			On(fx, func(s *TestContainer) {})
+		}
+		c.Add(fx)
+	}
+
+	state := &TestContainer{}
+	b.ReportAllocs()
+	for b.Loop() {
+		c.Evaluate(state)
+	}
+}
@@ -11,6 +11,8 @@ type PreAttackRoll struct {
 	Target     *Actor
 	Expression *expression.Expression
 	Tags       tag.Container
+	// CriticalOnHit turns any hit into a critical hit
+	CriticalOnHit bool
 }
 
 type PostAttackRoll struct {
@@ -26,15 +28,47 @@ type AttributeCalculation struct {
 	Attribute  tag.Tag
 }
 
+type DamageTraitsCalculation struct {
+	Source *Actor
+	Traits DamageTraits
+}
+
+type PreInitiative struct {
+	Source     *Actor
+	Expression *expression.Expression
+}
+
+type PostInitiative struct {
+	Source *Actor
+	Result *expression.Expression
+}
+
 type PreTakeDamage struct {
 	Expression *expression.Expression
 	Source     *Actor
 }
 
 type PostTakeDamage struct {
-	Result       *expression.Expression
-	Source       *Actor
-	ActualDamage int
+	Result          *expression.Expression
+	Source          *Actor
+	ActualDamage    int
+	TemporaryDamage int
+}
+
+type TemporaryHitPointsDepleted struct {
+	Source *Actor
+	Result *expression.Expression
+}
+
+type PreHeal struct {
+	Expression *expression.Expression
+	Source     *Actor
+}
+
+type PostHeal struct {
+	Result        *expression.Expression
+	Source        *Actor
+	ActualHealing int
 }
 
 type PreDamageRoll struct {
@@ -56,6 +90,7 @@ type PreSavingThrow struct {
 	Source          *Actor
 	Attribute       tag.Tag
 	DifficultyClass int
+	AutoFail        bool
 }
 
 type PostSavingThrow struct {
@@ -65,6 +100,25 @@ type PostSavingThrow struct {
 	DifficultyClass int
 }
 
+// PreAbilityCheck is also evaluated for passive scores, where the d20 is never rolled
+type PreAbilityCheck struct {
+	Expression      *expression.Expression
+	Source          *Actor
+	Attribute       tag.Tag
+	Skill           tag.Tag
+	DifficultyClass int
+	AutoFail        bool
+	Passive         bool
+}
+
+type PostAbilityCheck struct {
+	Result          *expression.Expression
+	Source          *Actor
+	Attribute       tag.Tag
+	Skill           tag.Tag
+	DifficultyClass int
+}
+
 type AttributeChanged struct {
 	Source    *Actor
 	Attribute tag.Tag
@@ -78,6 +132,10 @@ type ConditionChanged struct {
 	From      *Effect
 }
 
+type LongRestFinished struct {
+	Source *Actor
+}
+
 type TurnStarted struct {
 	Source *Actor
 }
@@ -86,6 +144,12 @@ type TurnEnded struct {
 	Source *Actor
 }
 
+type AttackAllowed struct {
+	Source  *Actor
+	Target  *Actor
+	Allowed bool
+}
+
 type PreMoveStep struct {
 	Source  *Actor
 	Action  Action
@@ -93,3 +157,18 @@ type PreMoveStep struct {
 	To      grid.Position
 	CanMove bool
 }
+
+type EffectExpired struct {
+	Source *Actor
+	Effect *Effect
+}
+
+type AuraEntered struct {
+	Aura   *Aura
+	Source *Actor
+}
+
+type AuraLeft struct {
+	Aura   *Aura
+	Source *Actor
+}
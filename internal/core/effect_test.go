@@ -6,7 +6,6 @@ import (
 	"anvil/internal/expression"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 )
 
 type testState struct {
@@ -21,7 +20,7 @@ func TestEffect_Evaluate(t *testing.T) {
 	t.Run("handler executes when event matches", func(t *testing.T) {
 		e := &Effect{}
 		called := false
-		e.withHandler("TestEffect", func(_ *Effect, _ any) {
+		On(e, func(_ *TestEffect) {
 			called = true
 		})
 		e.Evaluate(&TestEffect{})
@@ -31,7 +30,7 @@ func TestEffect_Evaluate(t *testing.T) {
 	t.Run("handler does not execute when event does not match", func(t *testing.T) {
 		e := &Effect{}
 		called := false
-		e.withHandler("TestEffect", func(_ *Effect, _ any) {
+		On(e, func(_ *TestEffect) {
 			called = true
 		})
 
@@ -43,13 +42,33 @@ func TestEffect_Evaluate(t *testing.T) {
 		e := &Effect{}
 		expr := expression.FromConstant(10, "test")
 		state := &TestEffect{Expression: expr}
-		e.withHandler("TestEffect", func(_ *Effect, s any) {
-			estate, ok := s.(*TestEffect)
-			require.True(t, ok, "state must be *TestEffect for test to continue")
-			estate.Expression.AddConstant(5, "test")
+		On(e, func(s *TestEffect) {
+			s.Expression.AddConstant(5, "test")
 		})
 		e.Evaluate(state)
 		state.Expression.Evaluate()
 		assert.Equal(t, 15, state.Expression.Value)
 	})
 }
+
+func BenchmarkEffect_Evaluate(b *testing.B) {
+	b.Run("handled", func(b *testing.B) {
+		e := &Effect{}
+		On(e, func(s *TestEffect) {})
+		state := &TestEffect{}
+		b.ReportAllocs()
+		for b.Loop() {
+			e.Evaluate(state)
+		}
+	})
+
+	b.Run("unhandled", func(b *testing.B) {
+		e := &Effect{}
+		On(e, func(s *TestEffect) {})
+		state := &testState{}
+		b.ReportAllocs()
+		for b.Loop() {
+			e.Evaluate(state)
+		}
+	})
+}
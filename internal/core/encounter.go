@@ -1,24 +1,37 @@
 package core
 
 import (
+	"maps"
 	"slices"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/tag"
 )
 
 type Encounter struct {
 	Round           int
 	Turn            int
 	InitiativeOrder []*Actor
+	Initiative      map[*Actor]int
 	Actors          []*Actor
 	Dispatcher      EventDispatcher
 	World           *World
+	Factions        *Factions
+	Conditions      []VictoryCondition
+	outcome         *Outcome
+	leaving         []*Actor
+	// delayed actors already started their turn this round and resume it when their new slot comes up
+	delayed []*Actor
+	// turnResources are the resources of the active actor when its turn started, to tell whether it acted
+	turnResources map[tag.Tag]int
 }
 
 func (e *Encounter) Start() {
 	for _, a := range e.Actors {
 		a.Encounter = e
 	}
-	e.InitiativeOrder = slices.Clone(e.Actors)
 	e.Dispatcher.Begin(EncounterEvent{Actors: e.Actors, World: e.World})
+	e.rollInitiative()
 	e.Round = -1
 	e.startRound()
 	e.startTurn()
@@ -31,25 +44,187 @@ func (e *Encounter) End() {
 }
 
 func (e *Encounter) EndTurn() {
-	e.ActiveActor().EndTurn()
+	active := e.ActiveActor()
+	active.EndTurn()
+	e.tickDurations(DurationTurnEnd, active)
 	e.Dispatcher.End()
-	if e.IsOver() {
+	for _, a := range e.leaving {
+		e.removeActor(a)
+	}
+	e.leaving = nil
+	if e.decide() {
 		e.endRound()
 		e.Dispatcher.End() // End the encounter when it's over
 		return
 	}
-	e.Turn++
+	// An active actor that left already handed its slot to the next actor
+	if e.Turn < len(e.InitiativeOrder) && e.InitiativeOrder[e.Turn] == active {
+		e.Turn++
+	}
 	if e.Turn >= len(e.InitiativeOrder) {
 		e.endRound()
+		if e.decide() {
+			e.Dispatcher.End()
+			return
+		}
 		e.startRound()
 	}
 	e.startTurn()
 }
 
+// decide checks the victory conditions at a turn or round boundary and settles the outcome once one is met
+func (e *Encounter) decide() bool {
+	conditions := append(AnyOf{}, e.Conditions...)
+	outcome, ok := append(conditions, Elimination{}).Check(e)
+	if !ok {
+		return false
+	}
+
+	e.outcome = &outcome
+	e.Dispatcher.Emit(OutcomeEvent{Outcome: outcome})
+	return true
+}
+
+// AddActor joins an actor to a running encounter, it rolls initiative and acts this round only if its slot
+// is still to come
+func (e *Encounter) AddActor(actor *Actor) {
+	actor.Encounter = e
+	e.Actors = append(e.Actors, actor)
+	if e.Initiative == nil {
+		return
+	}
+
+	e.Dispatcher.Begin(JoinEncounterEvent{Actor: actor})
+	defer e.Dispatcher.End()
+	actor.World.UpdateAuras()
+	e.Initiative[actor] = actor.RollInitiative()
+	if e.insert(actor) <= e.Turn {
+		e.Turn++
+	}
+	e.Dispatcher.Emit(InitiativeEvent{Order: e.InitiativeOrder, Initiative: e.Initiative})
+}
+
+// SetRelationship changes how two factions regard each other, it can flip mid-fight
+func (e *Encounter) SetRelationship(a, b TeamID, relationship Relationship) {
+	if e.Factions == nil {
+		e.Factions = NewFactions()
+	}
+
+	old := e.Factions.Relationship(a, b)
+	e.Factions.Set(a, b, relationship)
+	e.Dispatcher.Emit(RelationshipChangedEvent{From: a, To: b, OldValue: old, Relationship: relationship})
+}
+
+// RemoveActor takes an actor out of the encounter and off the map, the active actor leaves once its turn ends
+func (e *Encounter) RemoveActor(actor *Actor) {
+	if !slices.Contains(e.Actors, actor) || slices.Contains(e.leaving, actor) {
+		return
+	}
+
+	if e.Initiative != nil && actor == e.ActiveActor() {
+		e.leaving = append(e.leaving, actor)
+		return
+	}
+
+	e.removeActor(actor)
+}
+
+func (e *Encounter) removeActor(actor *Actor) {
+	e.Dispatcher.Begin(LeaveEncounterEvent{Actor: actor})
+	defer e.Dispatcher.End()
+	e.remove(actor)
+	e.Actors = slices.DeleteFunc(e.Actors, func(a *Actor) bool { return a == actor })
+	delete(e.Initiative, actor)
+	e.delayed = slices.DeleteFunc(e.delayed, func(a *Actor) bool { return a == actor })
+	actor.World.RemoveOccupant(actor.Position, actor)
+	actor.World.UpdateAuras()
+	actor.Encounter = nil
+}
+
+// Delay moves an actor whose slot is still to come this round to a lower initiative. The active actor can delay
+// before it spends anything, its turn passes to the next actor and resumes once its new slot comes up
+func (e *Encounter) Delay(actor *Actor, initiative int) {
+	idx := slices.Index(e.InitiativeOrder, actor)
+	active := idx == e.Turn
+	switch {
+	case idx == -1:
+		panic("only actors in initiative order can delay")
+	case initiative > e.Initiative[actor]:
+		panic("delay can only lower initiative")
+	case idx < e.Turn:
+		panic("actors that already acted this round cannot delay")
+	case active && !maps.Equal(actor.Resources.Current, e.turnResources):
+		panic("the active actor can only delay before spending anything")
+	}
+
+	e.Initiative[actor] = initiative
+	e.remove(actor)
+	slot := e.insert(actor)
+	resumes := active && slot != e.Turn
+	if resumes {
+		e.Dispatcher.End()
+		e.delayed = append(e.delayed, actor)
+	}
+	e.Dispatcher.Emit(InitiativeEvent{Order: e.InitiativeOrder, Initiative: e.Initiative})
+	if resumes {
+		e.startTurn()
+	}
+}
+
+func (e *Encounter) rollInitiative() {
+	e.Initiative = make(map[*Actor]int, len(e.Actors))
+	for _, a := range e.Actors {
+		e.Initiative[a] = a.RollInitiative()
+	}
+
+	e.InitiativeOrder = slices.Clone(e.Actors)
+	slices.SortStableFunc(e.InitiativeOrder, e.compareInitiative)
+	e.Dispatcher.Emit(InitiativeEvent{Order: e.InitiativeOrder, Initiative: e.Initiative})
+}
+
+// compareInitiative puts the highest initiative first, breaking ties by dexterity and then by who joined first
+func (e *Encounter) compareInitiative(a *Actor, b *Actor) int {
+	if diff := e.Initiative[b] - e.Initiative[a]; diff != 0 {
+		return diff
+	}
+
+	dexA := a.Attribute(tags.AttributeDexterity).Value
+	dexB := b.Attribute(tags.AttributeDexterity).Value
+	if diff := dexB - dexA; diff != 0 {
+		return diff
+	}
+
+	return slices.Index(e.Actors, a) - slices.Index(e.Actors, b)
+}
+
+// insert places an actor in initiative order and returns its slot, callers keep Turn on the active actor
+func (e *Encounter) insert(actor *Actor) int {
+	idx, _ := slices.BinarySearchFunc(e.InitiativeOrder, actor, e.compareInitiative)
+	e.InitiativeOrder = slices.Insert(e.InitiativeOrder, idx, actor)
+	return idx
+}
+
+// remove takes an actor out of initiative order, the slot of a removed active actor goes to the next actor
+func (e *Encounter) remove(actor *Actor) {
+	idx := slices.Index(e.InitiativeOrder, actor)
+	if idx == -1 {
+		return
+	}
+
+	e.InitiativeOrder = slices.Delete(e.InitiativeOrder, idx, idx+1)
+	if idx < e.Turn {
+		e.Turn--
+	}
+}
+
 func (e *Encounter) startRound() {
 	e.Round++
-	e.Dispatcher.Begin(RoundEvent{Round: e.Round, Actors: e.Actors})
+	e.Dispatcher.Begin(RoundEvent{Round: e.Round, Actors: e.InitiativeOrder})
 	e.Turn = 0
+	e.delayed = nil
+	if e.Round > 0 {
+		e.tickDurations(DurationRounds, nil)
+	}
 }
 
 func (e *Encounter) endRound() {
@@ -57,6 +232,13 @@ func (e *Encounter) endRound() {
 }
 
 func (e *Encounter) startTurn() {
-	e.Dispatcher.Begin(TurnEvent{Turn: e.Turn, Actor: e.ActiveActor()})
-	e.ActiveActor().StartTurn()
+	active := e.ActiveActor()
+	e.Dispatcher.Begin(TurnEvent{Turn: e.Turn, Actor: active})
+	if slices.Contains(e.delayed, active) {
+		e.delayed = slices.DeleteFunc(e.delayed, func(a *Actor) bool { return a == active })
+	} else {
+		e.tickDurations(DurationTurnStart, active)
+		active.StartTurn()
+	}
+	e.turnResources = maps.Clone(active.Resources.Current)
 }
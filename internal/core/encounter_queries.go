@@ -1,14 +1,18 @@
 package core
 
+// IsOver is true once a victory condition ended the encounter or no living actor is hostile to another one
 func (e Encounter) IsOver() bool {
-	alive := 0
-	teams := []TeamID{TeamPlayers, TeamEnemies}
-	for _, t := range teams {
-		if !e.IsTeamDead(t) {
-			alive++
-		}
+	_, ok := e.Outcome()
+	return ok
+}
+
+// Outcome is the decided result of the encounter, until a boundary decides it only elimination is checked
+func (e Encounter) Outcome() (Outcome, bool) {
+	if e.outcome != nil {
+		return *e.outcome, true
 	}
-	return alive <= 1
+
+	return Elimination{}.Check(&e)
 }
 
 func (e Encounter) IsTeamDead(team TeamID) bool {
@@ -24,11 +28,48 @@ func (e Encounter) ActiveActor() *Actor {
 	return e.InitiativeOrder[e.Turn]
 }
 
+// CompletedRounds counts the rounds every actor has acted in
+func (e Encounter) CompletedRounds() int {
+	if e.Turn >= len(e.InitiativeOrder) {
+		return e.Round + 1
+	}
+	return e.Round
+}
+
+func (e Encounter) Relationship(a, b TeamID) Relationship {
+	return e.Factions.Relationship(a, b)
+}
+
+// Winner is the first winning team, see Winners when several factions share the victory
 func (e Encounter) Winner() (TeamID, bool) {
+	winners := e.Winners()
+	if len(winners) == 0 {
+		return "", false
+	}
+	return winners[0], true
+}
+
+func (e Encounter) Winners() []TeamID {
+	outcome, _ := e.Outcome()
+	return outcome.Winners
+}
+
+func (e Encounter) alive() []*Actor {
+	alive := make([]*Actor, 0, len(e.Actors))
 	for _, c := range e.Actors {
 		if !c.IsDead() {
-			return c.Team, true
+			alive = append(alive, c)
+		}
+	}
+	return alive
+}
+
+func (e Encounter) hostileSurvivors(team TeamID) []TeamID {
+	var hostile []*Actor
+	for _, c := range e.alive() {
+		if e.Relationship(team, c.Team) == Hostile {
+			hostile = append(hostile, c)
 		}
 	}
-	return "", false
+	return teamsOf(hostile)
 }
@@ -0,0 +1,310 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/eventbus"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+)
+
+func newTestEncounter(rolls []int, dexterity ...int) *Encounter {
+	world := NewWorld(loader.WorldDefinition{Width: 5, Height: 5})
+	world.SetRoller(&sequenceRoller{values: rolls})
+	dispatcher := &eventbus.Dispatcher{}
+	encounter := &Encounter{Dispatcher: dispatcher, World: world}
+	for i, dex := range dexterity {
+		team := "enemies"
+		if i%2 == 0 {
+			team = "players"
+		}
+		actor := NewActor(dispatcher, world, grid.Position{X: i, Y: 0}, loader.ActorDefinition{
+			Name:         string(rune('A' + i)),
+			Team:         team,
+			HitPoints:    10,
+			MaxHitPoints: 10,
+			Attributes:   loader.AttributesDefinition{Dexterity: dex},
+		})
+		encounter.Actors = append(encounter.Actors, actor)
+	}
+
+	return encounter
+}
+
+func names(actors []*Actor) []string {
+	result := make([]string, len(actors))
+	for i, a := range actors {
+		result[i] = a.Name
+	}
+
+	return result
+}
+
+func TestEncounter_Initiative(t *testing.T) {
+	t.Run("orders by initiative roll", func(t *testing.T) {
+		encounter := newTestEncounter([]int{5, 18, 11}, 10, 10, 10)
+		encounter.Start()
+
+		assert.Equal(t, []string{"B", "C", "A"}, names(encounter.InitiativeOrder))
+		assert.Equal(t, 18, encounter.Initiative[encounter.Actors[1]])
+		assert.Equal(t, "B", encounter.ActiveActor().Name)
+	})
+
+	t.Run("breaks ties by dexterity", func(t *testing.T) {
+		encounter := newTestEncounter([]int{10, 10, 10}, 10, 12, 14)
+		encounter.Start()
+
+		assert.Equal(t, []string{"C", "B", "A"}, names(encounter.InitiativeOrder))
+	})
+
+	t.Run("breaks full ties by joining order", func(t *testing.T) {
+		encounter := newTestEncounter([]int{10, 10, 10}, 10, 10, 10)
+		encounter.Start()
+
+		assert.Equal(t, []string{"A", "B", "C"}, names(encounter.InitiativeOrder))
+	})
+
+	t.Run("effects can grant advantage", func(t *testing.T) {
+		encounter := newTestEncounter([]int{3, 15, 10}, 10, 10)
+		fx := &Effect{Name: "Alert"}
+		On(fx, func(s *PreInitiative) { s.Expression.GiveAdvantage(fx.Name) })
+		encounter.Actors[0].AddEffect(fx)
+		encounter.Start()
+
+		assert.Equal(t, 15, encounter.Initiative[encounter.Actors[0]])
+		assert.Equal(t, []string{"A", "B"}, names(encounter.InitiativeOrder))
+	})
+}
+
+func TestEncounter_Combatants(t *testing.T) {
+	newCombatant := func(encounter *Encounter, name string, team string) *Actor {
+		return NewActor(&eventbus.Dispatcher{}, encounter.World, grid.Position{X: 4, Y: 4}, loader.ActorDefinition{
+			Name:         name,
+			Team:         team,
+			HitPoints:    10,
+			MaxHitPoints: 10,
+			Attributes:   loader.AttributesDefinition{Dexterity: 10},
+		})
+	}
+
+	t.Run("joining before the active actor keeps the turn", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6, 20}, 10, 10, 10)
+		encounter.Start()
+		encounter.EndTurn()
+		assert.Equal(t, "B", encounter.ActiveActor().Name)
+
+		summon := newCombatant(encounter, "D", "players")
+		encounter.AddActor(summon)
+
+		assert.Equal(t, []string{"D", "A", "B", "C"}, names(encounter.InitiativeOrder))
+		assert.Equal(t, "B", encounter.ActiveActor().Name)
+		assert.Equal(t, encounter, summon.Encounter)
+		assert.Contains(t, encounter.Actors, summon)
+	})
+
+	t.Run("joining after the active actor acts this round", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6, 10}, 10, 10, 10)
+		encounter.Start()
+		encounter.AddActor(newCombatant(encounter, "D", "players"))
+
+		encounter.EndTurn()
+		encounter.EndTurn()
+		assert.Equal(t, "D", encounter.ActiveActor().Name)
+	})
+
+	t.Run("leaving after acting keeps the turn", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6}, 10, 10, 10)
+		encounter.Start()
+		encounter.EndTurn()
+		fled := encounter.Actors[0]
+
+		encounter.RemoveActor(fled)
+
+		assert.Equal(t, "B", encounter.ActiveActor().Name)
+		assert.Len(t, encounter.Actors, 2)
+		assert.Nil(t, fled.Encounter)
+		assert.False(t, encounter.World.At(fled.Position).IsOccupied())
+	})
+
+	t.Run("the active actor leaves when its turn ends", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6, 4}, 10, 10, 10, 10)
+		encounter.Start()
+		encounter.EndTurn()
+
+		encounter.RemoveActor(encounter.ActiveActor())
+		assert.Equal(t, "B", encounter.ActiveActor().Name)
+
+		encounter.EndTurn()
+		assert.Equal(t, []string{"A", "C", "D"}, names(encounter.InitiativeOrder))
+		assert.Equal(t, "C", encounter.ActiveActor().Name)
+	})
+
+	t.Run("delaying passes the turn on", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6}, 10, 10, 10)
+		encounter.Start()
+
+		encounter.Delay(encounter.ActiveActor(), 8)
+
+		assert.Equal(t, []string{"B", "A", "C"}, names(encounter.InitiativeOrder))
+		assert.Equal(t, "B", encounter.ActiveActor().Name)
+		encounter.EndTurn()
+		assert.Equal(t, "A", encounter.ActiveActor().Name)
+	})
+
+	t.Run("delaying a waiting actor keeps the turn", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6}, 10, 10, 10)
+		encounter.Start()
+
+		encounter.Delay(encounter.Actors[1], 2)
+
+		assert.Equal(t, []string{"A", "C", "B"}, names(encounter.InitiativeOrder))
+		assert.Equal(t, "A", encounter.ActiveActor().Name)
+	})
+
+	countTurnStarts := func(actor *Actor) *int {
+		count := 0
+		fx := &Effect{Name: "Counter"}
+		On(fx, func(_ *TurnStarted) { count++ })
+		actor.AddEffect(fx)
+		return &count
+	}
+
+	t.Run("a delayed actor resumes its turn without starting it again", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6}, 10, 10, 10)
+		starts := countTurnStarts(encounter.Actors[0])
+		encounter.Start()
+
+		encounter.Delay(encounter.ActiveActor(), 8)
+		encounter.EndTurn()
+
+		assert.Equal(t, "A", encounter.ActiveActor().Name)
+		assert.Equal(t, 1, *starts)
+		encounter.EndTurn()
+		assert.Equal(t, "C", encounter.ActiveActor().Name)
+	})
+
+	t.Run("delaying into its own slot keeps the turn", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6}, 10, 10, 10)
+		starts := countTurnStarts(encounter.Actors[0])
+		encounter.Start()
+
+		encounter.Delay(encounter.ActiveActor(), 15)
+
+		assert.Equal(t, []string{"A", "B", "C"}, names(encounter.InitiativeOrder))
+		assert.Equal(t, "A", encounter.ActiveActor().Name)
+		assert.Equal(t, 1, *starts)
+	})
+
+	t.Run("the active actor cannot delay after spending resources", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6}, 10, 10, 10)
+		encounter.Start()
+		encounter.ActiveActor().ConsumeResource(tags.ResourceAction, 1)
+
+		assert.Panics(t, func() { encounter.Delay(encounter.ActiveActor(), 8) })
+		assert.Equal(t, []string{"A", "B", "C"}, names(encounter.InitiativeOrder))
+	})
+
+	t.Run("actors that already acted cannot delay", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6}, 10, 10, 10)
+		encounter.Start()
+		encounter.EndTurn()
+		encounter.EndTurn()
+
+		assert.Panics(t, func() { encounter.Delay(encounter.Actors[0], 2) })
+		assert.Equal(t, []string{"A", "B", "C"}, names(encounter.InitiativeOrder))
+		assert.Equal(t, "C", encounter.ActiveActor().Name)
+	})
+
+	t.Run("actors outside initiative order cannot delay", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6}, 10, 10, 10)
+		encounter.Start()
+		outsider := newCombatant(encounter, "D", "players")
+
+		assert.Panics(t, func() { encounter.Delay(outsider, 2) })
+		assert.NotContains(t, encounter.InitiativeOrder, outsider)
+	})
+}
+
+func TestEncounter_Factions(t *testing.T) {
+	newFactionEncounter := func(teams ...string) *Encounter {
+		encounter := newTestEncounter(nil, make([]int, len(teams))...)
+		for i, team := range teams {
+			encounter.Actors[i].Team = TeamFromString(team)
+			encounter.Actors[i].Encounter = encounter
+		}
+		return encounter
+	}
+	kill := func(a *Actor) { a.Conditions.Add(tags.Dead, &Effect{Name: "Death"}) }
+
+	t.Run("unknown teams become their own faction", func(t *testing.T) {
+		assert.Equal(t, TeamPlayers, TeamFromString("players"))
+		assert.Equal(t, TeamEnemies, TeamFromString(""))
+		assert.Equal(t, TeamID("Bandits"), TeamFromString("bandits"))
+	})
+
+	t.Run("different factions are hostile by default", func(t *testing.T) {
+		encounter := newFactionEncounter("players", "bandits", "monster")
+		players, bandits, monster := encounter.Actors[0], encounter.Actors[1], encounter.Actors[2]
+
+		assert.True(t, players.IsHostileTo(bandits))
+		assert.True(t, bandits.IsHostileTo(monster))
+		assert.False(t, encounter.IsOver())
+
+		kill(monster)
+		assert.False(t, encounter.IsOver())
+
+		kill(bandits)
+		assert.True(t, encounter.IsOver())
+		assert.Equal(t, []TeamID{TeamPlayers}, encounter.Winners())
+	})
+
+	t.Run("allied factions win together", func(t *testing.T) {
+		encounter := newFactionEncounter("players", "bandits", "monster")
+		encounter.Factions = NewFactions()
+		encounter.Factions.Set("Players", "Bandits", Allied)
+
+		assert.False(t, encounter.Actors[0].IsHostileTo(encounter.Actors[1]))
+		assert.False(t, encounter.IsOver())
+
+		kill(encounter.Actors[2])
+		assert.True(t, encounter.IsOver())
+		assert.Equal(t, []TeamID{TeamPlayers, "Bandits"}, encounter.Winners())
+	})
+
+	t.Run("neutral factions do not keep the fight going", func(t *testing.T) {
+		encounter := newFactionEncounter("players", "bandits")
+		encounter.Factions = NewFactions()
+		encounter.Factions.Set("Bandits", "Players", Neutral)
+
+		assert.True(t, encounter.IsOver())
+	})
+
+	t.Run("relationships can change mid-fight", func(t *testing.T) {
+		encounter := newFactionEncounter("players", "bandits")
+		var changes []RelationshipChangedEvent
+		encounter.Dispatcher.(*eventbus.Dispatcher).Subscribe(eventbus.EventType(RelationshipChangedEvent{}), func(e eventbus.Event) {
+			if e.End {
+				changes = append(changes, e.Data.(RelationshipChangedEvent))
+			}
+		})
+
+		encounter.SetRelationship(TeamPlayers, "Bandits", Allied)
+
+		assert.True(t, encounter.IsOver())
+		assert.Equal(t, []RelationshipChangedEvent{{From: TeamPlayers, To: "Bandits", OldValue: Hostile, Relationship: Allied}}, changes)
+
+		encounter.SetRelationship("Bandits", TeamPlayers, Hostile)
+		assert.False(t, encounter.IsOver())
+	})
+
+	t.Run("a team can turn on itself", func(t *testing.T) {
+		encounter := newFactionEncounter("players", "players")
+		assert.True(t, encounter.IsOver())
+
+		encounter.SetRelationship(TeamPlayers, TeamPlayers, Hostile)
+		assert.False(t, encounter.IsOver())
+	})
+}
@@ -12,6 +12,35 @@ type EncounterEvent struct {
 	World  *World
 }
 
+type OutcomeEvent struct {
+	Outcome Outcome
+}
+
+type JoinEncounterEvent struct {
+	Actor *Actor
+}
+
+type LeaveEncounterEvent struct {
+	Actor *Actor
+}
+
+type RelationshipChangedEvent struct {
+	From         TeamID
+	To           TeamID
+	OldValue     Relationship
+	Relationship Relationship
+}
+
+type InitiativeRollEvent struct {
+	Source     *Actor
+	Expression *expression.Expression
+}
+
+type InitiativeEvent struct {
+	Order      []*Actor
+	Initiative map[*Actor]int
+}
+
 type RoundEvent struct {
 	Round  int
 	Actors []*Actor
@@ -67,8 +96,16 @@ type AttackRollEvent struct {
 }
 
 type TakeDamageEvent struct {
-	Target *Actor
-	Damage *expression.Expression
+	Target          *Actor
+	Damage          *expression.Expression
+	TemporaryDamage int
+	ActualDamage    int
+}
+
+type HealEvent struct {
+	Target        *Actor
+	Healing       *expression.Expression
+	ActualHealing int
 }
 
 type UseActionEvent struct {
@@ -88,6 +125,33 @@ type EffectEvent struct {
 	Effect *Effect
 }
 
+type ConcentrationEvent struct {
+	Source *Actor
+	Spell  string
+}
+
+type ConcentrationBrokenEvent struct {
+	Source *Actor
+	Spell  string
+	Reason string
+}
+
+type ReactionEvent struct {
+	Source   *Actor
+	Reaction *Reaction
+}
+
+type AuraChangedEvent struct {
+	Aura    *Aura
+	Actor   *Actor
+	Entered bool
+}
+
+type EffectExpiredEvent struct {
+	Source *Actor
+	Effect *Effect
+}
+
 type AttributeChangeEvent struct {
 	Source    *Actor
 	Attribute tag.Tag
@@ -103,6 +167,19 @@ type SavingThrowEvent struct {
 	DifficultyClass int
 }
 
+type AbilityCheckEvent struct {
+	Expression      *expression.Expression
+	Source          *Actor
+	Attribute       tag.Tag
+	Skill           tag.Tag
+	DifficultyClass int
+}
+
+type ContestedCheckEvent struct {
+	Source *Actor
+	Target *Actor
+}
+
 type SpendResourceEvent struct {
 	Source   *Actor
 	Resource tag.Tag
@@ -116,6 +193,26 @@ type ConditionChangedEvent struct {
 	Added     bool
 }
 
+type ConditionLevelChangedEvent struct {
+	Source    *Actor
+	Condition tag.Tag
+	From      *Effect
+	OldLevel  int
+	Level     int
+}
+
+// CastSpellEvent is emitted when a spell is cast, Slot is 0 for cantrips and rituals
+type CastSpellEvent struct {
+	Source *Actor
+	Spell  string
+	Level  int
+	Slot   int
+}
+
+type LongRestEvent struct {
+	Source *Actor
+}
+
 type MoveEvent struct {
 	World  *World
 	Source *Actor
@@ -7,5 +7,6 @@ type Item interface {
 	Archetype() string
 	ID() string
 	OnEquip(a *Actor)
+	OnUnequip(a *Actor)
 	Tags() *tag.Container
 }
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"reflect"
+	"slices"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/tag"
+)
+
+// ReactionProvider is implemented by actions that can be used as a reaction outside their owner's turn
+type ReactionProvider interface {
+	Reactions() []*Reaction
+}
+
+type reactionTrigger struct {
+	eligible func(any) bool
+	respond  func(any)
+}
+
+// Reaction answers a triggering state, the engine asks its owner first, charges the cost and then runs the
+// response while the triggering event is still in progress
+type Reaction struct {
+	Name     string
+	Owner    *Actor
+	Prompt   string
+	Cost     map[tag.Tag]int
+	triggers map[reflect.Type]reactionTrigger
+}
+
+func NewReaction(owner *Actor, name string, prompt string) *Reaction {
+	return &Reaction{
+		Name:   name,
+		Owner:  owner,
+		Prompt: prompt,
+		Cost:   map[tag.Tag]int{tags.ResourceReaction: 1},
+	}
+}
+
+// OnTrigger registers a trigger on a state type, respond only runs when eligible holds and the owner accepts
+func OnTrigger[T any](r *Reaction, eligible func(*T) bool, respond func(*T)) {
+	if r.triggers == nil {
+		r.triggers = make(map[reflect.Type]reactionTrigger)
+	}
+
+	r.triggers[reflect.TypeFor[*T]()] = reactionTrigger{
+		eligible: func(state any) bool { return eligible(state.(*T)) },
+		respond:  func(state any) { respond(state.(*T)) },
+	}
+}
+
+func (a *Actor) Reactions() []*Reaction {
+	var reactions []*Reaction
+	for _, action := range a.Actions {
+		if provider, ok := action.(ReactionProvider); ok {
+			reactions = append(reactions, provider.Reactions()...)
+		}
+	}
+	return reactions
+}
+
+func (a *Actor) react(state any) {
+	if a.Encounter != nil {
+		a.Encounter.React(state)
+	}
+}
+
+// React offers every actor in the encounter the chance to respond to a state, each actor uses at most one
+// reaction per trigger
+func (e *Encounter) React(state any) {
+	stateType := reflect.TypeOf(state)
+	options := []RequestOption{
+		{Value: true, Label: "Yes", Default: true},
+		{Value: false, Label: "No"},
+	}
+
+	for _, reactor := range slices.Clone(e.Actors) {
+		if e.IsOver() {
+			return
+		}
+
+		if !reactor.CanAct() {
+			continue
+		}
+
+		for _, r := range reactor.Reactions() {
+			trigger, ok := r.triggers[stateType]
+			if !ok || !reactor.Resources.CanAfford(r.Cost) || !trigger.eligible(state) {
+				continue
+			}
+
+			response := reactor.World.Ask(reactor, r.Prompt, options)
+			if accepted, ok := response.Value.(bool); !ok || !accepted {
+				continue
+			}
+
+			e.respond(reactor, r, trigger, state)
+			break
+		}
+	}
+}
+
+func (e *Encounter) respond(reactor *Actor, r *Reaction, trigger reactionTrigger, state any) {
+	reactor.Dispatcher.Begin(ReactionEvent{Source: reactor, Reaction: r})
+	defer reactor.Dispatcher.End()
+	for t, amount := range r.Cost {
+		reactor.ConsumeResource(t, amount)
+	}
+	trigger.respond(state)
+}
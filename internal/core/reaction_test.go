@@ -0,0 +1,119 @@
+package core
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/grid"
+	"anvil/internal/tag"
+)
+
+type reactionAction struct {
+	reactions []*Reaction
+}
+
+func (a *reactionAction) Name() string                                        { return "reaction" }
+func (a *reactionAction) Archetype() string                                   { return "reaction" }
+func (a *reactionAction) ID() string                                          { return "reaction" }
+func (a *reactionAction) Tags() *tag.Container                                { return &tag.Container{} }
+func (a *reactionAction) Perform(_ []grid.Position)                           {}
+func (a *reactionAction) ValidPositions(_ grid.Position) []grid.Position      { return nil }
+func (a *reactionAction) AffectedPositions(_ []grid.Position) []grid.Position { return nil }
+func (a *reactionAction) AverageDamage() int                                  { return 0 }
+func (a *reactionAction) Reactions() []*Reaction                              { return a.reactions }
+
+// answerRequests answers every prompt until the test ends and counts them
+func answerRequests(t *testing.T, world *World, accept bool) *atomic.Int32 {
+	asked := &atomic.Int32{}
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			if request := world.RequestManager().GetPendingRequest(); request != nil && !request.Resolved {
+				asked.Add(1)
+				request.Answer(RequestOption{Value: accept})
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	return asked
+}
+
+func TestEncounter_React(t *testing.T) {
+	// B attacks A, A can raise a shield when hit
+	setup := func(rolls ...int) (*Encounter, *Actor, *Actor) {
+		encounter := newTestEncounter(rolls, 10, 10)
+		defender, attacker := encounter.Actors[0], encounter.Actors[1]
+		for _, a := range encounter.Actors {
+			a.Encounter = encounter
+		}
+
+		shield := NewReaction(defender, "Shield", "Cast Shield?")
+		OnTrigger(shield, func(s *PostAttackRoll) bool {
+			return s.Target == defender && s.Result.Value >= defender.ArmorClass().Value
+		}, func(s *PostAttackRoll) {
+			fx := &Effect{Name: "Shield", Duration: UntilStartOfTurn(defender, 1)}
+			On(fx, func(c *AttributeCalculation) {
+				if c.Attribute == tags.ActorArmorClass {
+					c.Expression.AddConstant(5, fx.Name)
+				}
+			})
+			defender.AddEffect(fx)
+		})
+		defender.AddAction(&reactionAction{reactions: []*Reaction{shield}})
+		return encounter, defender, attacker
+	}
+
+	t.Run("responds inside the triggering event", func(t *testing.T) {
+		encounter, defender, attacker := setup(12)
+		asked := answerRequests(t, encounter.World, true)
+
+		result := attacker.AttackRoll(defender, tag.Container{})
+
+		assert.False(t, result.Success)
+		assert.Equal(t, 15, result.Against)
+		assert.Equal(t, int32(1), asked.Load())
+		assert.Equal(t, 0, defender.Resources.Remaining(tags.ResourceReaction))
+	})
+
+	t.Run("declining keeps the reaction", func(t *testing.T) {
+		encounter, defender, attacker := setup(12)
+		answerRequests(t, encounter.World, false)
+
+		result := attacker.AttackRoll(defender, tag.Container{})
+
+		assert.True(t, result.Success)
+		assert.Equal(t, 1, defender.Resources.Remaining(tags.ResourceReaction))
+	})
+
+	t.Run("ineligible reactors are not asked", func(t *testing.T) {
+		encounter, defender, attacker := setup(5)
+		asked := answerRequests(t, encounter.World, true)
+
+		attacker.AttackRoll(defender, tag.Container{})
+
+		assert.Equal(t, int32(0), asked.Load())
+	})
+
+	t.Run("a spent reaction cannot be used again", func(t *testing.T) {
+		encounter, defender, attacker := setup(12, 12)
+		asked := answerRequests(t, encounter.World, true)
+
+		attacker.AttackRoll(defender, tag.Container{})
+		defender.RemoveEffect(defender.Effects.All()[0])
+		result := attacker.AttackRoll(defender, tag.Container{})
+
+		assert.True(t, result.Success)
+		assert.Equal(t, int32(1), asked.Load())
+	})
+}
@@ -1,45 +1,73 @@
 package core
 
-import "errors"
+import (
+	"errors"
+	"sync"
+)
 
 type RequestManager struct {
+	mu            sync.Mutex
 	activeRequest *Request
+	onRequest     func(request *Request)
 }
 
 func NewRequestManager() *RequestManager {
 	return &RequestManager{}
 }
 
+// OnRequest calls handler on the asking goroutine with every new request, the handler may answer it right away
+func (rm *RequestManager) OnRequest(handler func(request *Request)) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.onRequest = handler
+}
+
 func (rm *RequestManager) Ask(actor *Actor, text string, options []RequestOption) (RequestOption, error) {
+	rm.mu.Lock()
 	if rm.activeRequest != nil {
+		rm.mu.Unlock()
 		return RequestOption{}, errors.New("there is already a pending request, please wait until it is resolved")
 	}
 
-	rm.activeRequest = &Request{
+	request := &Request{
 		Target:   actor,
 		Text:     text,
 		Options:  options,
-		Response: make(chan RequestOption),
+		Response: make(chan RequestOption, 1),
+	}
+	rm.activeRequest = request
+	handler := rm.onRequest
+	rm.mu.Unlock()
+
+	if handler != nil {
+		handler(request)
 	}
 
-	selectedOption := <-rm.activeRequest.Response
+	selectedOption := <-request.Response
+	rm.mu.Lock()
 	rm.activeRequest = nil
+	rm.mu.Unlock()
 	return selectedOption, nil
 }
 
 func (rm *RequestManager) HasPendingRequest() bool {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
 	return rm.activeRequest != nil
 }
 
 func (rm *RequestManager) GetPendingRequest() *Request {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
 	return rm.activeRequest
 }
 
 func (rm *RequestManager) AnswerDefault() error {
-	if rm.activeRequest == nil {
+	request := rm.GetPendingRequest()
+	if request == nil {
 		return errors.New("no pending request to answer")
 	}
 
-	rm.activeRequest.AnswerWithDefault()
+	request.AnswerWithDefault()
 	return nil
 }
@@ -60,6 +60,26 @@ func TestRequestManager(t *testing.T) {
 		})
 	})
 
+	t.Run("Request Handler", func(t *testing.T) {
+		t.Run("should let the handler answer on the asking goroutine", func(t *testing.T) {
+			rm := NewRequestManager()
+			actor := &Actor{Name: "TestActor"}
+			options := []RequestOption{
+				{Label: "Option 1", Value: "value1"},
+				{Label: "Option 2", Value: "value2"},
+			}
+			rm.OnRequest(func(request *Request) {
+				assert.Same(t, request, rm.GetPendingRequest())
+				request.Answer(request.Options[1])
+			})
+
+			result, err := rm.Ask(actor, "Test question", options)
+			require.NoError(t, err)
+			assert.Equal(t, "Option 2", result.Label)
+			assert.False(t, rm.HasPendingRequest())
+		})
+	})
+
 	t.Run("Pending Request Checks", func(t *testing.T) {
 		t.Run("should return false when no pending request", func(t *testing.T) {
 			rm := NewRequestManager()
@@ -22,6 +22,7 @@ func NewResourcesFromDefinition(def loader.ResourcesDefinition) Resources {
 	optionalResources := map[tag.Tag]int{
 		tags.ResourceFlySpeed:   def.FlySpeed,
 		tags.ResourceSwimSpeed:  def.SwimSpeed,
+		tags.ResourceAmmunition: def.Ammunition,
 		tags.ResourceSpellSlot1: def.SpellSlot1,
 		tags.ResourceSpellSlot2: def.SpellSlot2,
 		tags.ResourceSpellSlot3: def.SpellSlot3,
@@ -56,6 +57,12 @@ func (r *Resources) Reset() {
 	r.Current[tags.ResourceUsedSpeed] = 0
 }
 
+// ClearSpeed uses up the remaining movement of the turn
+func (r *Resources) ClearSpeed() {
+	r.init()
+	r.Current[tags.ResourceUsedSpeed] = r.maxSpeed()
+}
+
 func (r *Resources) LongRest() {
 	r.init()
 	maps.Copy(r.Current, r.Max)
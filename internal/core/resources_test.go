@@ -129,6 +129,20 @@ func TestResources(t *testing.T) {
 			assert.Equal(t, 20, resources.Remaining(tags.ResourceFlySpeed), "expected 20 fly speed remaining")
 			assert.Equal(t, 10, resources.Remaining(tags.ResourceWalkSpeed), "expected 10 walk speed remaining")
 		})
+
+		t.Run("should clear every movement type", func(t *testing.T) {
+			resources := Resources{
+				Max: map[tag.Tag]int{
+					tags.ResourceWalkSpeed: 30,
+					tags.ResourceFlySpeed:  40,
+				},
+			}
+			resources.Reset()
+
+			resources.ClearSpeed()
+			assert.Equal(t, 0, resources.Remaining(tags.ResourceWalkSpeed), "expected no walk speed remaining")
+			assert.Equal(t, 0, resources.Remaining(tags.ResourceFlySpeed), "expected no fly speed remaining")
+		})
 	})
 
 	t.Run("Custom Resources", func(t *testing.T) {
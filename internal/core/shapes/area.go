@@ -0,0 +1,154 @@
+package shapes
+
+import (
+	"math"
+
+	"anvil/internal/grid"
+)
+
+// FeetPerCell is the size of a grid square
+const FeetPerCell = 5
+
+type AreaKind string
+
+const (
+	AreaCone      AreaKind = "cone"
+	AreaCube      AreaKind = "cube"
+	AreaCylinder  AreaKind = "cylinder"
+	AreaEmanation AreaKind = "emanation"
+	AreaLine      AreaKind = "line"
+	AreaSphere    AreaKind = "sphere"
+)
+
+// Area is an area of effect template, sizes are in feet. Size is the length of cones and lines, the side of cubes,
+// the radius of spheres and cylinders and the reach of emanations. Width only applies to lines and defaults to 5 feet
+type Area struct {
+	Kind  AreaKind
+	Size  int
+	Width int
+	// FromSelf places a cube against the caster or centers a sphere or cylinder on it, cones, lines and
+	// emanations always come from the caster
+	FromSelf bool
+}
+
+// Origin is the point of origin of the area, lines of effect are traced from it
+func (a Area) Origin(caster grid.Position, target grid.Position) grid.Position {
+	switch a.Kind {
+	case AreaCone, AreaLine, AreaEmanation:
+		return caster
+	default:
+		if a.FromSelf {
+			return caster
+		}
+		return target
+	}
+}
+
+// Cells turns the template into grid squares, caster is where the effect comes from and target is the point it
+// is aimed at. A square is covered when its center falls inside the area, the 2024 rule of covering at least half
+// a square. Distances are counted like grid.Position.Distance, every diagonal square is 5 feet. Cones, lines,
+// emanations and cubes from the caster do not include the caster's own square
+func (a Area) Cells(caster grid.Position, target grid.Position) []grid.Position {
+	size := a.Size / FeetPerCell
+	switch a.Kind {
+	case AreaCone:
+		// the width of a cone at any point equals its distance from the origin
+		return directional(caster, target, size, func(distance int, across float64) bool {
+			return math.Abs(across) <= float64(distance)/2+epsilon
+		})
+	case AreaLine:
+		// a half-open band keeps exactly width squares, even widths lean to one side of the center line
+		half := float64(max(a.Width, FeetPerCell)/FeetPerCell) / 2
+		return directional(caster, target, size, func(_ int, across float64) bool {
+			return across > -half+epsilon && across <= half+epsilon
+		})
+	case AreaCube:
+		return cube(caster, target, size, a.FromSelf)
+	case AreaEmanation:
+		return emanation(caster, size)
+	case AreaSphere, AreaCylinder:
+		origin := a.Origin(caster, target)
+		return append(emanation(origin, size), origin)
+	default:
+		return nil
+	}
+}
+
+const epsilon = 1e-9
+
+// directional covers squares ahead of origin towards target up to length squares away, inside keeps a square from
+// its distance to origin and its signed offset from the center line
+func directional(origin grid.Position, target grid.Position, length int, inside func(distance int, across float64) bool) []grid.Position {
+	dx, dy := float64(target.X-origin.X), float64(target.Y-origin.Y)
+	norm := math.Hypot(dx, dy)
+	if norm == 0 || length <= 0 {
+		return []grid.Position{}
+	}
+
+	dx, dy = dx/norm, dy/norm
+	positions := make([]grid.Position, 0)
+	for y := -length; y <= length; y++ {
+		for x := -length; x <= length; x++ {
+			pos := grid.Position{X: origin.X + x, Y: origin.Y + y}
+			along := float64(x)*dx + float64(y)*dy
+			across := float64(x)*dy - float64(y)*dx
+			if along <= epsilon || !inside(origin.Distance(pos), across) {
+				continue
+			}
+
+			positions = append(positions, pos)
+		}
+	}
+
+	return positions
+}
+
+// cube is centered on target, or placed against the caster facing target when fromSelf
+func cube(caster grid.Position, target grid.Position, side int, fromSelf bool) []grid.Position {
+	if side <= 0 {
+		return []grid.Position{}
+	}
+
+	minX, minY := target.X-(side-1)/2, target.Y-(side-1)/2
+	if fromSelf {
+		minX, minY = faceAgainst(caster.X, target.X, side), faceAgainst(caster.Y, target.Y, side)
+		if caster == target {
+			minX = caster.X + 1
+		}
+	}
+
+	positions := make([]grid.Position, 0, side*side)
+	for y := minY; y < minY+side; y++ {
+		for x := minX; x < minX+side; x++ {
+			positions = append(positions, grid.Position{X: x, Y: y})
+		}
+	}
+
+	return positions
+}
+
+// faceAgainst places one axis of a cube next to from in the direction of to, centered when there is no direction
+func faceAgainst(from int, to int, side int) int {
+	switch {
+	case to > from:
+		return from + 1
+	case to < from:
+		return from - side
+	default:
+		return from - (side-1)/2
+	}
+}
+
+func emanation(origin grid.Position, distance int) []grid.Position {
+	positions := make([]grid.Position, 0, (2*distance+1)*(2*distance+1))
+	for y := -distance; y <= distance; y++ {
+		for x := -distance; x <= distance; x++ {
+			if x == 0 && y == 0 {
+				continue
+			}
+			positions = append(positions, grid.Position{X: origin.X + x, Y: origin.Y + y})
+		}
+	}
+
+	return positions
+}
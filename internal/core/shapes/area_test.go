@@ -0,0 +1,101 @@
+package shapes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/grid"
+)
+
+func TestArea_Cells(t *testing.T) {
+	origin := grid.Position{X: 0, Y: 0}
+	east := grid.Position{X: 5, Y: 0}
+
+	t.Run("cone widens with distance and skips the caster", func(t *testing.T) {
+		cells := Area{Kind: AreaCone, Size: 15}.Cells(origin, east)
+
+		assert.ElementsMatch(t, []grid.Position{
+			{X: 1, Y: 0},
+			{X: 2, Y: -1}, {X: 2, Y: 0}, {X: 2, Y: 1},
+			{X: 3, Y: -1}, {X: 3, Y: 0}, {X: 3, Y: 1},
+		}, cells)
+	})
+
+	t.Run("line is one square wide by default", func(t *testing.T) {
+		cells := Area{Kind: AreaLine, Size: 15}.Cells(origin, east)
+
+		assert.ElementsMatch(t, []grid.Position{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}}, cells)
+	})
+
+	t.Run("line follows diagonals", func(t *testing.T) {
+		cells := Area{Kind: AreaLine, Size: 10}.Cells(origin, grid.Position{X: 4, Y: 4})
+
+		assert.ElementsMatch(t, []grid.Position{{X: 1, Y: 1}, {X: 2, Y: 2}}, cells)
+	})
+
+	t.Run("cone reaches as far along diagonals", func(t *testing.T) {
+		cells := Area{Kind: AreaCone, Size: 15}.Cells(origin, grid.Position{X: 4, Y: 4})
+
+		assert.Contains(t, cells, grid.Position{X: 3, Y: 3})
+		assert.NotContains(t, cells, grid.Position{X: 4, Y: 4})
+	})
+
+	t.Run("wide line", func(t *testing.T) {
+		cells := Area{Kind: AreaLine, Size: 10, Width: 15}.Cells(origin, east)
+
+		assert.Len(t, cells, 6)
+	})
+
+	t.Run("line of an even width keeps exactly that many squares", func(t *testing.T) {
+		cells := Area{Kind: AreaLine, Size: 10, Width: 10}.Cells(origin, east)
+
+		assert.Len(t, cells, 4)
+		assert.Contains(t, cells, grid.Position{X: 1, Y: 0})
+		assert.Contains(t, cells, grid.Position{X: 2, Y: 0})
+	})
+
+	t.Run("cube from self sits against the caster", func(t *testing.T) {
+		cells := Area{Kind: AreaCube, Size: 15, FromSelf: true}.Cells(origin, east)
+
+		assert.Len(t, cells, 9)
+		assert.NotContains(t, cells, origin)
+		assert.Contains(t, cells, grid.Position{X: 1, Y: -1})
+		assert.Contains(t, cells, grid.Position{X: 3, Y: 1})
+	})
+
+	t.Run("cube at a point is centered on it", func(t *testing.T) {
+		cells := Area{Kind: AreaCube, Size: 15}.Cells(origin, east)
+
+		assert.Len(t, cells, 9)
+		assert.Contains(t, cells, grid.Position{X: 4, Y: -1})
+		assert.Contains(t, cells, grid.Position{X: 6, Y: 1})
+	})
+
+	t.Run("sphere and cylinder share a footprint around the point", func(t *testing.T) {
+		sphere := Area{Kind: AreaSphere, Size: 10}.Cells(origin, east)
+		cylinder := Area{Kind: AreaCylinder, Size: 10}.Cells(origin, east)
+
+		assert.Equal(t, sphere, cylinder)
+		assert.Contains(t, sphere, east)
+		assert.Contains(t, sphere, grid.Position{X: 7, Y: 0})
+		assert.Contains(t, sphere, grid.Position{X: 7, Y: 2})
+		assert.Len(t, sphere, 25)
+		assert.NotContains(t, sphere, origin)
+	})
+
+	t.Run("emanation surrounds the caster", func(t *testing.T) {
+		cells := Area{Kind: AreaEmanation, Size: 5}.Cells(origin, east)
+
+		assert.Len(t, cells, 8)
+		assert.NotContains(t, cells, origin)
+	})
+}
+
+func TestArea_Origin(t *testing.T) {
+	caster, target := grid.Position{X: 0, Y: 0}, grid.Position{X: 3, Y: 3}
+
+	assert.Equal(t, caster, Area{Kind: AreaCone}.Origin(caster, target))
+	assert.Equal(t, target, Area{Kind: AreaSphere}.Origin(caster, target))
+	assert.Equal(t, caster, Area{Kind: AreaSphere, FromSelf: true}.Origin(caster, target))
+}
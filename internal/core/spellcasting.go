@@ -0,0 +1,156 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/loader"
+)
+
+// MaxSpellLevel is the highest spell slot level
+const MaxSpellLevel = 9
+
+// SpellCaster is implemented by actions that cast a spell, level 0 is a cantrip
+type SpellCaster interface {
+	SpellLevel() int
+}
+
+// Spellbook lists the spells an actor knows and the ones it has prepared, spells are action archetypes. The actions
+// of known cantrips and prepared spells are in the owner's actions, the book adds and removes them as it changes
+type Spellbook struct {
+	Known    []string
+	Prepared []string
+	owner    *Actor
+	actions  map[string]Action
+}
+
+func NewSpellbookFromDefinition(owner *Actor, def loader.SpellsDefinition) *Spellbook {
+	book := &Spellbook{owner: owner, actions: make(map[string]Action)}
+	for _, spell := range def.Known {
+		book.Learn(spell)
+	}
+
+	for _, spell := range def.Prepared {
+		book.Learn(spell)
+		book.Prepare(spell)
+	}
+
+	return book
+}
+
+func (s *Spellbook) Learn(spell string) {
+	if !s.Knows(spell) {
+		s.Known = append(s.Known, spell)
+	}
+}
+
+// Bind sets the action that casts a known spell, the owner can use it whenever the spell is castable
+func (s *Spellbook) Bind(spell string, action Action) {
+	if !s.Knows(spell) {
+		return
+	}
+
+	s.actions[spell] = action
+	s.sync(spell)
+}
+
+// Prepare readies a known spell for casting, it returns false for spells the actor does not know
+func (s *Spellbook) Prepare(spell string) bool {
+	if !s.Knows(spell) {
+		return false
+	}
+
+	if !s.IsPrepared(spell) {
+		s.Prepared = append(s.Prepared, spell)
+	}
+
+	s.sync(spell)
+	return true
+}
+
+func (s *Spellbook) Unprepare(spell string) {
+	s.Prepared = slices.DeleteFunc(s.Prepared, func(p string) bool { return p == spell })
+	s.sync(spell)
+}
+
+func (s *Spellbook) Knows(spell string) bool {
+	return slices.Contains(s.Known, spell)
+}
+
+func (s *Spellbook) IsPrepared(spell string) bool {
+	return slices.Contains(s.Prepared, spell)
+}
+
+// IsCastable is true for prepared spells and known cantrips, cantrips never need to be prepared
+func (s *Spellbook) IsCastable(spell string) bool {
+	if s.IsPrepared(spell) {
+		return true
+	}
+
+	caster, ok := s.actions[spell].(SpellCaster)
+	return ok && s.Knows(spell) && caster.SpellLevel() == 0
+}
+
+// sync adds the action of spell to the owner's actions while it is castable and removes it otherwise
+func (s *Spellbook) sync(spell string) {
+	action, ok := s.actions[spell]
+	if !ok || s.owner == nil {
+		return
+	}
+
+	if s.IsCastable(spell) {
+		s.owner.AddAction(action)
+		return
+	}
+
+	s.owner.RemoveAction(action)
+}
+
+// SpellSlotLevels lists the slot levels from minimum up that still have a slot left
+func (a *Actor) SpellSlotLevels(minimum int) []int {
+	levels := make([]int, 0)
+	for level := max(minimum, 1); level <= MaxSpellLevel; level++ {
+		if a.Resources.Remaining(tags.ResourceSpellSlot(level)) > 0 {
+			levels = append(levels, level)
+		}
+	}
+
+	return levels
+}
+
+// ChooseSpellSlot picks the slot to cast spell with, the actor is asked when more than one level is available and
+// the lowest one is the default. It returns false when no slot of minimum level or higher is left
+func (a *Actor) ChooseSpellSlot(spell string, minimum int) (int, bool) {
+	levels := a.SpellSlotLevels(minimum)
+	switch len(levels) {
+	case 0:
+		return 0, false
+	case 1:
+		return levels[0], true
+	}
+
+	options := make([]RequestOption, 0, len(levels))
+	for i, level := range levels {
+		options = append(options, RequestOption{
+			Value:   level,
+			Label:   fmt.Sprintf("Level %d (%d left)", level, a.Resources.Remaining(tags.ResourceSpellSlot(level))),
+			Default: i == 0,
+		})
+	}
+
+	response := a.World.Ask(a, fmt.Sprintf("Cast %s with which spell slot?", spell), options)
+	if level, ok := response.Value.(int); ok && slices.Contains(levels, level) {
+		return level, true
+	}
+
+	return levels[0], true
+}
+
+// CastSpell spends the slot of the given level, a slot of level 0 is used for cantrips and rituals
+func (a *Actor) CastSpell(spell string, level int, slot int) {
+	a.Dispatcher.Emit(CastSpellEvent{Source: a, Spell: spell, Level: level, Slot: slot})
+	if slot > 0 {
+		a.ConsumeResource(tags.ResourceSpellSlot(slot), 1)
+	}
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/eventbus"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+	"anvil/internal/tag"
+)
+
+type spellAction struct {
+	name  string
+	level int
+}
+
+func (a *spellAction) Name() string                                        { return a.name }
+func (a *spellAction) Archetype() string                                   { return "spell" }
+func (a *spellAction) ID() string                                          { return a.name }
+func (a *spellAction) Tags() *tag.Container                                { return &tag.Container{} }
+func (a *spellAction) Perform(_ []grid.Position)                           {}
+func (a *spellAction) ValidPositions(_ grid.Position) []grid.Position      { return nil }
+func (a *spellAction) AffectedPositions(_ []grid.Position) []grid.Position { return nil }
+func (a *spellAction) AverageDamage() int                                  { return 0 }
+func (a *spellAction) SpellLevel() int                                     { return a.level }
+
+func TestSpellbook(t *testing.T) {
+	newWizard := func(def loader.SpellsDefinition) *Actor {
+		world := NewWorld(loader.WorldDefinition{Width: 3, Height: 3})
+		return NewActor(&eventbus.Dispatcher{}, world, grid.Position{X: 1, Y: 1}, loader.ActorDefinition{
+			Name:   "Wizard",
+			Spells: def,
+		})
+	}
+
+	t.Run("prepared spells are known", func(t *testing.T) {
+		book := newWizard(loader.SpellsDefinition{
+			Known:    []string{"sacred-flame"},
+			Prepared: []string{"burning-hands", "sacred-flame"},
+		}).Spellbook
+
+		assert.Equal(t, []string{"sacred-flame", "burning-hands"}, book.Known)
+		assert.True(t, book.IsPrepared("burning-hands"))
+	})
+
+	t.Run("only known spells can be prepared", func(t *testing.T) {
+		book := newWizard(loader.SpellsDefinition{}).Spellbook
+		assert.False(t, book.Prepare("thunderwave"))
+
+		book.Learn("thunderwave")
+		assert.True(t, book.Prepare("thunderwave"))
+		assert.True(t, book.IsPrepared("thunderwave"))
+
+		book.Unprepare("thunderwave")
+		assert.False(t, book.IsPrepared("thunderwave"))
+		assert.True(t, book.Knows("thunderwave"))
+	})
+
+	t.Run("preparing a spell adds its action and unpreparing removes it", func(t *testing.T) {
+		wizard := newWizard(loader.SpellsDefinition{Known: []string{"thunderwave"}})
+		thunderwave := &spellAction{name: "Thunderwave", level: 1}
+		wizard.Spellbook.Bind("thunderwave", thunderwave)
+		assert.NotContains(t, wizard.Actions, thunderwave)
+
+		wizard.Spellbook.Prepare("thunderwave")
+		assert.Contains(t, wizard.Actions, thunderwave)
+
+		wizard.Spellbook.Unprepare("thunderwave")
+		assert.NotContains(t, wizard.Actions, thunderwave)
+	})
+
+	t.Run("known cantrips are castable without preparing them", func(t *testing.T) {
+		wizard := newWizard(loader.SpellsDefinition{Known: []string{"fire-bolt"}})
+		fireBolt := &spellAction{name: "Fire Bolt"}
+		wizard.Spellbook.Bind("fire-bolt", fireBolt)
+
+		assert.True(t, wizard.Spellbook.IsCastable("fire-bolt"))
+		assert.Contains(t, wizard.Actions, fireBolt)
+
+		wizard.Spellbook.Unprepare("fire-bolt")
+		assert.Contains(t, wizard.Actions, fireBolt)
+	})
+}
+
+func TestActor_ChooseSpellSlot(t *testing.T) {
+	newCaster := func(resources loader.ResourcesDefinition) *Actor {
+		world := NewWorld(loader.WorldDefinition{Width: 3, Height: 3})
+		return NewActor(&eventbus.Dispatcher{}, world, grid.Position{X: 1, Y: 1}, loader.ActorDefinition{
+			Name:      "Wizard",
+			Resources: resources,
+		})
+	}
+
+	t.Run("fails without a slot of the spell level", func(t *testing.T) {
+		caster := newCaster(loader.ResourcesDefinition{SpellSlot1: 2})
+
+		_, ok := caster.ChooseSpellSlot("Scorching Ray", 2)
+		assert.False(t, ok)
+	})
+
+	t.Run("uses the only level left without asking", func(t *testing.T) {
+		caster := newCaster(loader.ResourcesDefinition{SpellSlot1: 1, SpellSlot3: 1})
+
+		slot, ok := caster.ChooseSpellSlot("Scorching Ray", 2)
+		assert.True(t, ok)
+		assert.Equal(t, 3, slot)
+	})
+
+	t.Run("asks for the level and defaults to the lowest", func(t *testing.T) {
+		caster := newCaster(loader.ResourcesDefinition{SpellSlot1: 1, SpellSlot2: 1, SpellSlot3: 1})
+		caster.World.RequestManager().OnRequest(func(request *Request) {
+			assert.Len(t, request.Options, 2)
+			assert.Equal(t, 2, request.DefaultOption().Value)
+			request.Answer(request.Options[1])
+		})
+
+		slot, ok := caster.ChooseSpellSlot("Scorching Ray", 2)
+		assert.True(t, ok)
+		assert.Equal(t, 3, slot)
+	})
+
+	t.Run("casting spends the slot", func(t *testing.T) {
+		caster := newCaster(loader.ResourcesDefinition{SpellSlot2: 2})
+
+		caster.CastSpell("Scorching Ray", 2, 2)
+		assert.Equal(t, 1, caster.Resources.Remaining(tags.ResourceSpellSlot2))
+
+		caster.CastSpell("Fire Bolt", 0, 0)
+		assert.Equal(t, 1, caster.Resources.Remaining(tags.ResourceSpellSlot2))
+	})
+}
@@ -34,6 +34,25 @@ func (a Attributes) Value(tag tag.Tag) int {
 	return 0
 }
 
+// Field points at the score for an attribute tag, nil when the tag isn't an attribute
+func (a *Attributes) Field(tag tag.Tag) *int {
+	switch tag {
+	case tags.AttributeStrength:
+		return &a.Strength
+	case tags.AttributeDexterity:
+		return &a.Dexterity
+	case tags.AttributeConstitution:
+		return &a.Constitution
+	case tags.AttributeIntelligence:
+		return &a.Intelligence
+	case tags.AttributeWisdom:
+		return &a.Wisdom
+	case tags.AttributeCharisma:
+		return &a.Charisma
+	}
+	return nil
+}
+
 func AttributeModifier(value int) int {
 	if value < 1 {
 		return -5 // minimum modifier for a score of 1
@@ -7,7 +7,9 @@ import (
 
 type Proficiencies struct {
 	Skills tag.Container
-	Bonus  int
+	// Expertise doubles the bonus of skills the actor is proficient in
+	Expertise tag.Container
+	Bonus     int
 }
 
 func NewProficienciesFromDefinition(def loader.ProficienciesDefinition) Proficiencies {
@@ -15,6 +17,9 @@ func NewProficienciesFromDefinition(def loader.ProficienciesDefinition) Proficie
 	for _, skill := range def.Skills {
 		proficiencies.Add(tag.FromString(skill))
 	}
+	for _, skill := range def.Expertise {
+		proficiencies.AddExpertise(tag.FromString(skill))
+	}
 	return proficiencies
 }
 
@@ -22,11 +27,23 @@ func (p *Proficiencies) Add(tag tag.Tag) {
 	p.Skills.AddTag(tag)
 }
 
+func (p *Proficiencies) AddExpertise(tag tag.Tag) {
+	p.Add(tag)
+	p.Expertise.AddTag(tag)
+}
+
 func (p Proficiencies) Has(tags tag.Container) bool {
 	return tags.MatchAny(p.Skills)
 }
 
+func (p Proficiencies) HasExpertise(tags tag.Container) bool {
+	return tags.MatchAny(p.Expertise)
+}
+
 func (p Proficiencies) Value(tags tag.Container) int {
+	if p.HasExpertise(tags) {
+		return p.Bonus * 2
+	}
 	if p.Has(tags) {
 		return p.Bonus
 	}
@@ -53,3 +53,13 @@ func TestProficiencies_Has(t *testing.T) {
 		assert.False(t, prof.Has(tag.ContainerFromString("weapon.martial")))
 	})
 }
+
+func TestProficiencies_Expertise(t *testing.T) {
+	prof := stats.Proficiencies{Bonus: 2}
+	prof.Add(tag.FromString("athletics"))
+	prof.AddExpertise(tag.FromString("stealth"))
+
+	assert.Equal(t, 2, prof.Value(tag.ContainerFromString("athletics")))
+	assert.Equal(t, 4, prof.Value(tag.ContainerFromString("stealth")))
+	assert.True(t, prof.Has(tag.ContainerFromString("stealth")), "expertise implies proficiency")
+}
@@ -1,6 +1,7 @@
 package tags
 
 import (
+	"fmt"
 	"slices"
 	"strings"
 
@@ -18,6 +19,8 @@ var (
 	ActorHitPoints  = tag.FromString("Actor.Defense.HitPoints")
 	ActorArmorClass = tag.FromString("Actor.Defense.ArmorClass")
 
+	ActorTemporaryHitPoints = tag.FromString("Actor.Defense.TemporaryHitPoints")
+
 	Resource                = tag.FromString("Actor.Resource")
 	ResourceAction          = tag.FromString("Actor.Resource.Action")
 	ResourceReaction        = tag.FromString("Actor.Resource.Reaction")
@@ -29,6 +32,7 @@ var (
 	ResourceWalkSpeed       = tag.FromString("Actor.Resource.Speed.Walk")
 	ResourceFlySpeed        = tag.FromString("Actor.Resource.Speed.Fly")
 	ResourceSwimSpeed       = tag.FromString("Actor.Resource.Speed.Swim")
+	ResourceAmmunition      = tag.FromString("Actor.Resource.Ammunition")
 
 	ResourceSpellSlot1 = tag.FromString("Actor.Resource.SpellSlot.1")
 	ResourceSpellSlot2 = tag.FromString("Actor.Resource.SpellSlot.2")
@@ -78,13 +82,21 @@ var (
 	Radiant     = tag.FromString("Damage.Kind.Radiant")
 	Fire        = tag.FromString("Damage.Kind.Fire")
 	Force       = tag.FromString("Damage.Kind.Force")
+	Acid        = tag.FromString("Damage.Kind.Acid")
+	Cold        = tag.FromString("Damage.Kind.Cold")
+	Lightning   = tag.FromString("Damage.Kind.Lightning")
+	Necrotic    = tag.FromString("Damage.Kind.Necrotic")
+	Psychic     = tag.FromString("Damage.Kind.Psychic")
+	Thunder     = tag.FromString("Damage.Kind.Thunder")
 
-	Melee  = tag.FromString("Melee")
-	Ranged = tag.FromString("Ranged")
+	Melee     = tag.FromString("Melee")
+	Ranged    = tag.FromString("Ranged")
+	LongRange = tag.FromString("Ranged.Long")
 
 	Attack       = tag.FromString("Attack")
 	WeaponAttack = tag.FromString("Attack.Weapon")
 	Spell        = tag.FromString("Attack.Spell")
+	SpellSave    = tag.FromString("Attack.Spell.Save")
 	Teleport     = tag.FromString("Teleport")
 
 	Move  = tag.FromString("Action.Move")
@@ -94,10 +106,21 @@ var (
 
 	Evocation = tag.FromString("School.Evocation")
 
+	Ritual            = tag.FromString("Spell.Ritual")
+	Component         = tag.FromString("Spell.Component")
+	VerbalComponent   = tag.FromString("Spell.Component.Verbal")
+	SomaticComponent  = tag.FromString("Spell.Component.Somatic")
+	MaterialComponent = tag.FromString("Spell.Component.Material")
+
 	Item = tag.FromString("Item")
 
 	Weapon        = tag.FromString("Item.Weapon")
 	Finesse       = tag.FromString("Item.Weapon.Finesse")
+	Thrown        = tag.FromString("Item.Weapon.Thrown")
+	Ammunition    = tag.FromString("Item.Weapon.Ammunition")
+	Heavy         = tag.FromString("Item.Weapon.Heavy")
+	TwoHanded     = tag.FromString("Item.Weapon.TwoHanded")
+	Versatile     = tag.FromString("Item.Weapon.Versatile")
 	NaturalWeapon = tag.FromString("Item.Weapon.Natural")
 	MartialWeapon = tag.FromString("Item.Weapon.Martial")
 	MartialAxe    = tag.FromString("Item.Weapon.Martial.Axe")
@@ -110,13 +133,54 @@ var (
 	Shield       = tag.FromString("Item.Armor.Shield")
 
 	Condition     = tag.FromString("Condition")
+	Blinded       = tag.FromString("Condition.Blinded")
+	Charmed       = tag.FromString("Condition.Charmed")
+	Deafened      = tag.FromString("Condition.Deafened")
+	Exhaustion    = tag.FromString("Condition.Exhaustion")
+	Frightened    = tag.FromString("Condition.Frightened")
+	Grappled      = tag.FromString("Condition.Grappled")
+	Invisible     = tag.FromString("Condition.Invisible")
 	Poisoned      = tag.FromString("Condition.Poisoned")
+	Prone         = tag.FromString("Condition.Prone")
+	Restrained    = tag.FromString("Condition.Restrained")
 	Stable        = tag.FromString("Condition.Stable")
 	Incapacitated = tag.FromString("Condition.Incapacitated")
+	Paralyzed     = tag.FromString("Condition.Incapacitated.Paralyzed")
+	Petrified     = tag.FromString("Condition.Incapacitated.Petrified")
+	Stunned       = tag.FromString("Condition.Incapacitated.Stunned")
 	Unconscious   = tag.FromString("Condition.Incapacitated.Unconscious")
 	Dead          = tag.FromString("Condition.Incapacitated.Unconscious.Dead")
 )
 
+// DamageKindFromString maps a bare damage kind such as "fire" to its Damage.Kind tag
+func DamageKindFromString(kind string) tag.Tag {
+	t := tag.FromString(kind)
+	if t.Match(DamageKind) {
+		return t
+	}
+
+	return tag.FromString(DamageKind.AsString() + "." + kind)
+}
+
+// ResourceSpellSlot returns the resource tag of a spell slot level, from 1 to 9
+func ResourceSpellSlot(level int) tag.Tag {
+	return tag.FromString(fmt.Sprintf("Actor.Resource.SpellSlot.%d", level))
+}
+
+// ComponentFromString maps a spell component such as "V" or "verbal" to its Spell.Component tag
+func ComponentFromString(component string) tag.Tag {
+	switch strings.ToLower(component) {
+	case "v", "verbal":
+		return VerbalComponent
+	case "s", "somatic":
+		return SomaticComponent
+	case "m", "material":
+		return MaterialComponent
+	default:
+		return tag.FromString(Component.AsString() + "." + component)
+	}
+}
+
 func ToReadable(tag tag.Tag) string {
 	ignore := []string{
 		"actor",
@@ -1,5 +1,7 @@
 package core
 
+import "strings"
+
 type TeamID string
 
 const (
@@ -7,13 +9,63 @@ const (
 	TeamEnemies TeamID = "Enemies"
 )
 
+// TeamFromString turns a definition team into a faction, unknown names become their own faction
 func TeamFromString(s string) TeamID {
 	switch s {
 	case "players":
 		return TeamPlayers
-	case "enemies":
+	case "enemies", "":
 		return TeamEnemies
 	default:
-		return TeamEnemies
+		return TeamID(strings.ToUpper(s[:1]) + s[1:])
+	}
+}
+
+type Relationship string
+
+const (
+	Allied  Relationship = "Allied"
+	Neutral Relationship = "Neutral"
+	Hostile Relationship = "Hostile"
+)
+
+type teamPair struct {
+	a, b TeamID
+}
+
+func pairOf(a, b TeamID) teamPair {
+	if b < a {
+		a, b = b, a
+	}
+	return teamPair{a: a, b: b}
+}
+
+// Factions is the relationship matrix between teams, members of a team are allied and different teams are
+// hostile unless set otherwise
+type Factions struct {
+	relationships map[teamPair]Relationship
+}
+
+func NewFactions() *Factions {
+	return &Factions{relationships: map[teamPair]Relationship{}}
+}
+
+func (f *Factions) Set(a, b TeamID, relationship Relationship) {
+	if f.relationships == nil {
+		f.relationships = map[teamPair]Relationship{}
+	}
+	f.relationships[pairOf(a, b)] = relationship
+}
+
+func (f *Factions) Relationship(a, b TeamID) Relationship {
+	if f != nil {
+		if r, ok := f.relationships[pairOf(a, b)]; ok {
+			return r
+		}
+	}
+
+	if a == b {
+		return Allied
 	}
+	return Hostile
 }
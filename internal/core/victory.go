@@ -0,0 +1,174 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"anvil/internal/grid"
+)
+
+// Outcome is how an encounter ended, no winners means everybody lost
+type Outcome struct {
+	Winners []TeamID
+	Reason  string
+}
+
+// VictoryCondition decides whether an encounter is over, conditions are checked at every turn and round
+// boundary and the first one met ends the encounter
+type VictoryCondition interface {
+	Check(e *Encounter) (Outcome, bool)
+}
+
+// Elimination is met once no living actor is hostile to another living actor, it always applies after
+// the encounter's own conditions
+type Elimination struct{}
+
+func (Elimination) Check(e *Encounter) (Outcome, bool) {
+	alive := e.alive()
+	for i, a := range alive {
+		for _, o := range alive[i+1:] {
+			if e.Relationship(a.Team, o.Team) == Hostile {
+				return Outcome{}, false
+			}
+		}
+	}
+
+	if len(alive) == 0 {
+		return Outcome{Reason: "Nobody is left standing"}, true
+	}
+
+	return Outcome{Winners: teamsOf(alive), Reason: "All hostile actors are defeated"}, true
+}
+
+// SurviveRounds lets a team win once it outlasts the given number of rounds
+type SurviveRounds struct {
+	Team   TeamID
+	Rounds int
+}
+
+func (s SurviveRounds) Check(e *Encounter) (Outcome, bool) {
+	if e.CompletedRounds() < s.Rounds || e.IsTeamDead(s.Team) {
+		return Outcome{}, false
+	}
+
+	return Outcome{Winners: []TeamID{s.Team}, Reason: fmt.Sprintf("Survived %d rounds", s.Rounds)}, true
+}
+
+// Protect makes a team lose when the protected actor dies
+type Protect struct {
+	Team   TeamID
+	Target *Actor
+}
+
+func (p Protect) Check(e *Encounter) (Outcome, bool) {
+	if !p.Target.IsDead() {
+		return Outcome{}, false
+	}
+
+	return Outcome{Winners: e.hostileSurvivors(p.Team), Reason: fmt.Sprintf("%s was killed", p.Target.Name)}, true
+}
+
+// DefeatActor lets a team win once a specific actor, usually a boss, is dead
+type DefeatActor struct {
+	Team   TeamID
+	Target *Actor
+}
+
+func (d DefeatActor) Check(e *Encounter) (Outcome, bool) {
+	if !d.Target.IsDead() {
+		return Outcome{}, false
+	}
+
+	return Outcome{Winners: []TeamID{d.Team}, Reason: fmt.Sprintf("%s was defeated", d.Target.Name)}, true
+}
+
+// ReachTile lets a team win as soon as one of its living actors stands on any of the tiles
+type ReachTile struct {
+	Team  TeamID
+	Tiles []grid.Position
+}
+
+func (r ReachTile) Check(e *Encounter) (Outcome, bool) {
+	for _, a := range e.alive() {
+		if a.Team == r.Team && slices.Contains(r.Tiles, a.Position) {
+			return Outcome{Winners: []TeamID{r.Team}, Reason: fmt.Sprintf("%s reached the exit", a.Name)}, true
+		}
+	}
+
+	return Outcome{}, false
+}
+
+// Morale makes a team flee once the share of its fallen actors reaches Losses
+type Morale struct {
+	Team   TeamID
+	Losses float64
+}
+
+func (m Morale) Check(e *Encounter) (Outcome, bool) {
+	total, dead := 0, 0
+	for _, a := range e.Actors {
+		if a.Team != m.Team {
+			continue
+		}
+
+		total++
+		if a.IsDead() {
+			dead++
+		}
+	}
+
+	if total == 0 || dead == total || float64(dead)/float64(total) < m.Losses {
+		return Outcome{}, false
+	}
+
+	return Outcome{Winners: e.hostileSurvivors(m.Team), Reason: fmt.Sprintf("%s morale broke", m.Team)}, true
+}
+
+// AllOf is met once every condition is met, the winners come from the first condition
+type AllOf []VictoryCondition
+
+func (conditions AllOf) Check(e *Encounter) (Outcome, bool) {
+	if len(conditions) == 0 {
+		return Outcome{}, false
+	}
+
+	reasons := make([]string, len(conditions))
+	var first Outcome
+	for i, c := range conditions {
+		outcome, ok := c.Check(e)
+		if !ok {
+			return Outcome{}, false
+		}
+
+		if i == 0 {
+			first = outcome
+		}
+		reasons[i] = outcome.Reason
+	}
+
+	return Outcome{Winners: first.Winners, Reason: strings.Join(reasons, " and ")}, true
+}
+
+// AnyOf is met by the first condition met
+type AnyOf []VictoryCondition
+
+func (conditions AnyOf) Check(e *Encounter) (Outcome, bool) {
+	for _, c := range conditions {
+		if outcome, ok := c.Check(e); ok {
+			return outcome, true
+		}
+	}
+
+	return Outcome{}, false
+}
+
+func teamsOf(actors []*Actor) []TeamID {
+	var teams []TeamID
+	for _, a := range actors {
+		if !slices.Contains(teams, a.Team) {
+			teams = append(teams, a.Team)
+		}
+	}
+	return teams
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/grid"
+)
+
+func TestEncounter_VictoryConditions(t *testing.T) {
+	// A and C are players, B is an enemy, turns go A, B, C
+	newVictoryEncounter := func(conditions ...VictoryCondition) *Encounter {
+		encounter := newTestEncounter([]int{18, 12, 6}, 10, 10, 10)
+		encounter.Conditions = conditions
+		return encounter
+	}
+	kill := func(a *Actor) { a.Conditions.Add(tags.Dead, &Effect{Name: "Death"}) }
+	playRound := func(e *Encounter) {
+		for range e.InitiativeOrder {
+			if e.IsOver() {
+				return
+			}
+			e.EndTurn()
+		}
+	}
+
+	t.Run("elimination applies without conditions", func(t *testing.T) {
+		encounter := newVictoryEncounter()
+		encounter.Start()
+		kill(encounter.Actors[1])
+		encounter.EndTurn()
+
+		outcome, ok := encounter.Outcome()
+		assert.True(t, ok)
+		assert.Equal(t, []TeamID{TeamPlayers}, outcome.Winners)
+		assert.Equal(t, "All hostile actors are defeated", outcome.Reason)
+	})
+
+	t.Run("survive rounds is checked at the round boundary", func(t *testing.T) {
+		encounter := newVictoryEncounter(SurviveRounds{Team: TeamPlayers, Rounds: 2})
+		encounter.Start()
+
+		playRound(encounter)
+		assert.False(t, encounter.IsOver())
+
+		playRound(encounter)
+		outcome, ok := encounter.Outcome()
+		assert.True(t, ok)
+		assert.Equal(t, 1, encounter.Round)
+		assert.Equal(t, []TeamID{TeamPlayers}, outcome.Winners)
+		assert.Equal(t, "Survived 2 rounds", outcome.Reason)
+	})
+
+	t.Run("losing a protected actor hands the win to its enemies", func(t *testing.T) {
+		encounter := newVictoryEncounter()
+		encounter.Conditions = []VictoryCondition{Protect{Team: TeamPlayers, Target: encounter.Actors[2]}}
+		encounter.Start()
+		kill(encounter.Actors[2])
+		assert.False(t, encounter.IsOver(), "conditions wait for a boundary")
+
+		encounter.EndTurn()
+		outcome, _ := encounter.Outcome()
+		assert.Equal(t, []TeamID{TeamEnemies}, outcome.Winners)
+		assert.Equal(t, "C was killed", outcome.Reason)
+	})
+
+	t.Run("reaching a tile wins", func(t *testing.T) {
+		encounter := newVictoryEncounter(ReachTile{Team: TeamPlayers, Tiles: []grid.Position{{X: 4, Y: 4}}})
+		encounter.Start()
+		encounter.Actors[0].Position = grid.Position{X: 4, Y: 4}
+		encounter.EndTurn()
+
+		outcome, ok := encounter.Outcome()
+		assert.True(t, ok)
+		assert.Equal(t, "A reached the exit", outcome.Reason)
+	})
+
+	t.Run("morale breaks before the team is wiped out", func(t *testing.T) {
+		encounter := newTestEncounter([]int{18, 12, 6, 4}, 10, 10, 10, 10)
+		encounter.Conditions = []VictoryCondition{Morale{Team: TeamEnemies, Losses: 0.5}}
+		encounter.Start()
+		kill(encounter.Actors[1])
+		encounter.EndTurn()
+
+		outcome, ok := encounter.Outcome()
+		assert.True(t, ok)
+		assert.Equal(t, []TeamID{TeamPlayers}, outcome.Winners)
+		assert.Equal(t, "Enemies morale broke", outcome.Reason)
+	})
+
+	t.Run("combined conditions need every objective", func(t *testing.T) {
+		encounter := newVictoryEncounter()
+		boss := encounter.Actors[1]
+		encounter.Conditions = []VictoryCondition{AllOf{
+			DefeatActor{Team: TeamPlayers, Target: boss},
+			SurviveRounds{Team: TeamPlayers, Rounds: 1},
+		}}
+		encounter.Start()
+		encounter.Actors = append(encounter.Actors, newTestActor(encounter.World, "Minion", grid.Position{X: 4, Y: 0}))
+		encounter.Actors[3].Team = TeamEnemies
+		kill(boss)
+
+		encounter.EndTurn()
+		assert.False(t, encounter.IsOver())
+
+		playRound(encounter)
+		outcome, ok := encounter.Outcome()
+		assert.True(t, ok)
+		assert.Equal(t, "B was defeated and Survived 1 rounds", outcome.Reason)
+	})
+}
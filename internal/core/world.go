@@ -2,9 +2,11 @@ package core
 
 import (
 	"math"
+	"time"
 
 	"anvil/internal/core/pathfinding"
 	"anvil/internal/core/shapes"
+	"anvil/internal/expression"
 	"anvil/internal/grid"
 	"anvil/internal/loader"
 )
@@ -13,14 +15,24 @@ type World struct {
 	Grid            *grid.Grid[WorldCell]
 	lineOfSightCalc *LineOfSightCalculator
 	requestManager  *RequestManager
+	roller          expression.Roller
+	seed            uint64
+	auras           []*Aura
 }
 
 func NewWorld(definition loader.WorldDefinition) *World {
+	seed := definition.Seed
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+
 	w := &World{
 		Grid: grid.New(definition.Width, definition.Height, func(pos grid.Position) WorldCell {
 			return WorldCell{Position: pos}
 		}),
 		requestManager: NewRequestManager(),
+		roller:         expression.NewSeededRoller(seed),
+		seed:           seed,
 	}
 	w.lineOfSightCalc = NewLineOfSightCalculator(w)
 	return w
@@ -89,6 +101,25 @@ func (w *World) HasLineOfSight(from grid.Position, to grid.Position) bool {
 	return w.lineOfSightCalc.HasLineOfSight(from, to)
 }
 
+// AreaOfEffect lists the squares of area aimed from caster at target that have line of effect from its origin
+func (w *World) AreaOfEffect(area shapes.Area, caster grid.Position, target grid.Position) []grid.Position {
+	origin := area.Origin(caster, target)
+	cells := make([]grid.Position, 0)
+	for _, pos := range area.Cells(caster, target) {
+		if !w.IsValidPosition(pos) || w.At(pos).Tile == Wall {
+			continue
+		}
+
+		if !w.HasLineOfSight(origin, pos) {
+			continue
+		}
+
+		cells = append(cells, pos)
+	}
+
+	return cells
+}
+
 func (w *World) FloodFill(start grid.Position, radius int) []grid.Position {
 	isBlocked := func(pos grid.Position) bool {
 		cell := w.Grid.At(pos)
@@ -117,3 +148,15 @@ func (w *World) Ask(actor *Actor, text string, options []RequestOption) RequestO
 func (w *World) RequestManager() *RequestManager {
 	return w.requestManager
 }
+
+func (w *World) Seed() uint64 {
+	return w.seed
+}
+
+func (w *World) Roller() expression.Roller {
+	return w.roller
+}
+
+func (w *World) SetRoller(roller expression.Roller) {
+	w.roller = roller
+}
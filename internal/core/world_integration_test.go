@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"anvil/internal/core/shapes"
 	"anvil/internal/grid"
 	"anvil/internal/loader"
 )
@@ -77,6 +78,16 @@ func TestWorldIntegration(t *testing.T) {
 
 			assert.Equal(t, 0, len(actors))
 		})
+
+		t.Run("should stop areas of effect at walls", func(t *testing.T) {
+			world := NewWorld(loader.WorldDefinition{Width: 5, Height: 5})
+			world.At(grid.Position{X: 2, Y: 1}).Tile = Wall
+			area := shapes.Area{Kind: shapes.AreaLine, Size: 20}
+
+			cells := world.AreaOfEffect(area, grid.Position{X: 0, Y: 1}, grid.Position{X: 4, Y: 1})
+
+			assert.Equal(t, []grid.Position{{X: 1, Y: 1}}, cells)
+		})
 	})
 
 	t.Run("Request Manager Integration", func(t *testing.T) {
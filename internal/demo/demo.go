@@ -38,10 +38,10 @@ func setupWorld(world *core.World) {
 	}
 }
 
-func New(dispatcher *eventbus.Dispatcher) *core.GameState {
+func New(dispatcher *eventbus.Dispatcher, seed uint64) *core.GameState {
 	registry := ruleset.NewRegistry()
 
-	world := core.NewWorld(loader.WorldDefinition{Width: 10, Height: 10})
+	world := core.NewWorld(loader.WorldDefinition{Width: 10, Height: 10, Seed: seed})
 	setupWorld(world)
 
 	cedric := setupPlayer(registry, dispatcher, world)
@@ -0,0 +1,57 @@
+package demo_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/ai"
+	"anvil/internal/demo"
+	"anvil/internal/eventbus"
+	"anvil/internal/prettyprint"
+)
+
+func playEncounter(seed uint64) string {
+	out := bytes.Buffer{}
+	dispatcher := eventbus.Dispatcher{}
+	dispatcher.SubscribeAll(func(msg eventbus.Event) {
+		prettyprint.Print(&out, msg)
+	})
+	state := demo.New(&dispatcher, seed)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			if state.World.RequestManager().HasPendingRequest() {
+				_ = state.World.RequestManager().AnswerDefault()
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	state.Encounter.Start()
+	for !state.Encounter.IsOver() {
+		ai.Play(state)
+	}
+
+	return out.String()
+}
+
+func TestDemo_Deterministic(t *testing.T) {
+	t.Run("same seed produces the same event stream", func(t *testing.T) {
+		first := playEncounter(1234)
+		second := playEncounter(1234)
+
+		assert.NotEmpty(t, first)
+		assert.Equal(t, first, second)
+	})
+}
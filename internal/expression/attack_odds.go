@@ -0,0 +1,45 @@
+package expression
+
+// AttackOdds are the chances of an attack roll against a fixed armor class
+// where a critical always hits and a natural 1 always misses
+type AttackOdds struct {
+	Hit      float64
+	Critical float64
+	Fumble   float64
+}
+
+func (e *Expression) AttackOdds(armorClass int) AttackOdds {
+	if len(e.Components) == 0 {
+		return AttackOdds{}
+	}
+
+	d20, ok := e.Components[0].(*D20Component)
+	if !ok {
+		return AttackOdds{Hit: e.Distribution().AtLeast(armorClass)}
+	}
+
+	faces := d20.Distribution()
+	modifiers := distributionOf(e.Components[1:])
+	odds := AttackOdds{}
+	for face := faces.Min(); face <= faces.Max(); face++ {
+		chance := faces.Probability(face)
+		switch {
+		case face >= d20.CriticalThreshold():
+			odds.Critical += chance
+			odds.Hit += chance
+		case face == 1:
+			odds.Fumble += chance
+		default:
+			odds.Hit += chance * modifiers.AtLeast(armorClass-face)
+		}
+	}
+
+	return odds
+}
+
+func (o AttackOdds) ExpectedDamage(damage *Expression) float64 {
+	critical := damage.Clone()
+	critical.DoubleDice("Critical")
+	normal := damage.Distribution().Mean()
+	return (o.Hit-o.Critical)*normal + o.Critical*critical.Distribution().Mean()
+}
@@ -19,4 +19,5 @@ type Component interface {
 	Clone() Component
 	Evaluate(ctx *Context) int
 	Expected() int
+	Distribution() Distribution
 }
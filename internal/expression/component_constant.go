@@ -41,6 +41,10 @@ func (c *ConstantComponent) Expected() int {
 	return c.value
 }
 
+func (c *ConstantComponent) Distribution() Distribution {
+	return pointDistribution(c.value)
+}
+
 func (c *ConstantComponent) Clone() Component {
 	components := make([]Component, len(c.components))
 	for i, component := range c.components {
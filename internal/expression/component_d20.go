@@ -1,22 +1,38 @@
 package expression
 
 import (
+	"math"
+	"slices"
+
 	"anvil/internal/mathi"
 	"anvil/internal/tag"
 )
 
+const naturalCritical = 20
+
 type D20Component struct {
-	value        int
-	values       []int
-	source       string
-	advantage    []string
-	disadvantage []string
-	tags         tag.Container
-	components   []Component
+	value          int
+	values         []int
+	source         string
+	advantage      []string
+	disadvantage   []string
+	critical       int
+	criticalSource string
+	modifiers      []DiceModifier
+	rolls          []DieRoll
+	tags           tag.Container
+	components     []Component
 }
 
 func newD20Component(tags tag.Container, source string, components ...Component) *D20Component {
-	return &D20Component{tags: tags, source: source, components: components, advantage: []string{}, disadvantage: []string{}}
+	return &D20Component{
+		tags:         tags,
+		source:       source,
+		components:   components,
+		advantage:    []string{},
+		disadvantage: []string{},
+		critical:     naturalCritical,
+	}
 }
 
 func (c *D20Component) Kind() ComponentKind {
@@ -43,13 +59,16 @@ func (c *D20Component) Evaluate(ctx *Context) int {
 	hasAdvantage := len(c.advantage) > 0
 	hasDisadvantage := len(c.disadvantage) > 0
 	isModified := (hasAdvantage || hasDisadvantage) && (!hasAdvantage || !hasDisadvantage)
+	rules := rulesOf(c.modifiers)
 	if !isModified {
-		c.values = []int{ctx.Rng.Roll(20)}
+		c.rolls = []DieRoll{rules.roll(ctx.Rng, 20)}
+		c.values = rollValues(c.rolls)
 		c.value = c.values[0]
 		return c.value
 	}
 
-	values := []int{ctx.Rng.Roll(20), ctx.Rng.Roll(20)}
+	c.rolls = []DieRoll{rules.roll(ctx.Rng, 20), rules.roll(ctx.Rng, 20)}
+	values := rollValues(c.rolls)
 	c.values = values
 	c.value = mathi.Min(values[0], values[1])
 
@@ -70,12 +89,57 @@ func (c *D20Component) GiveDisadvantage(source string) *D20Component {
 	return c
 }
 
+// RerollBelow rerolls a d20 that lands below threshold once, the new roll must be used
+func (c *D20Component) RerollBelow(threshold int, source string) *D20Component {
+	c.modifiers = append(c.modifiers, DiceModifier{Kind: DiceModifierReroll, Value: threshold, Source: source})
+	return c
+}
+
+// MinimumFace treats a d20 that lands below minimum as minimum
+func (c *D20Component) MinimumFace(minimum int, source string) *D20Component {
+	c.modifiers = append(c.modifiers, DiceModifier{Kind: DiceModifierMinimum, Value: minimum, Source: source})
+	return c
+}
+
+func (c *D20Component) Modifiers() []DiceModifier {
+	return c.modifiers
+}
+
+// Rolls are the d20s rolled, with their faces before and after modifiers
+func (c *D20Component) Rolls() []DieRoll {
+	return c.rolls
+}
+
+// ExpandCriticalRange makes natural rolls of threshold or higher critical, overlapping ranges don't stack
+func (c *D20Component) ExpandCriticalRange(threshold int, source string) *D20Component {
+	threshold = mathi.Max(threshold, 2)
+	if threshold >= c.CriticalThreshold() {
+		return c
+	}
+
+	c.critical = threshold
+	c.criticalSource = source
+	return c
+}
+
+func (c *D20Component) CriticalThreshold() int {
+	if c.critical == 0 {
+		return naturalCritical
+	}
+
+	return c.critical
+}
+
+func (c *D20Component) CriticalSource() string {
+	return c.criticalSource
+}
+
 func (c *D20Component) IsCritical() bool {
 	return c.IsCriticalSuccess() || c.IsCriticalFailure()
 }
 
 func (c *D20Component) IsCriticalSuccess() bool {
-	return c.value == 20
+	return c.value >= c.CriticalThreshold()
 }
 
 func (c *D20Component) IsCriticalFailure() bool {
@@ -91,8 +155,18 @@ func (c *D20Component) Disadvantage() []string {
 }
 
 func (c *D20Component) Expected() int {
-	// Average of d20 is 10.5, round to 11
-	return 11
+	return int(math.Round(c.Distribution().Mean()))
+}
+
+func (c *D20Component) Distribution() Distribution {
+	hasAdvantage := len(c.advantage) > 0
+	hasDisadvantage := len(c.disadvantage) > 0
+	die := rulesOf(c.modifiers).distribution(20)
+	if hasAdvantage == hasDisadvantage {
+		return die
+	}
+
+	return keptDiceDistribution(2, die, 1, hasDisadvantage)
 }
 
 func (c *D20Component) Clone() Component {
@@ -100,5 +174,15 @@ func (c *D20Component) Clone() Component {
 	for i, component := range c.components {
 		components[i] = component.Clone()
 	}
-	return &D20Component{value: c.value, tags: c.tags.Clone(), source: c.source, components: components}
+	return &D20Component{
+		value:          c.value,
+		advantage:      slices.Clone(c.advantage),
+		disadvantage:   slices.Clone(c.disadvantage),
+		critical:       c.critical,
+		criticalSource: c.criticalSource,
+		modifiers:      slices.Clone(c.modifiers),
+		tags:           c.tags.Clone(),
+		source:         c.source,
+		components:     components,
+	}
 }
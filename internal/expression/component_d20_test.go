@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"anvil/internal/expression"
 )
@@ -210,3 +211,101 @@ func TestD20Component_GiveDisadvantage(t *testing.T) {
 		assert.Equal(t, 5, expr.Value)
 	})
 }
+
+func TestD20Component_ExpandCriticalRange(t *testing.T) {
+	t.Run("critical on threshold or higher", func(t *testing.T) {
+		expr := expression.FromD20("attack")
+		expr.ExpandCriticalRange(19, "Improved Critical")
+		expr.Rng = newMockRoller(19)
+		expr.Evaluate()
+
+		d20, ok := expr.Components[0].(*expression.D20Component)
+		require.True(t, ok)
+		assert.True(t, d20.IsCriticalSuccess())
+		assert.Equal(t, 19, d20.CriticalThreshold())
+		assert.Equal(t, "Improved Critical", d20.CriticalSource())
+	})
+
+	t.Run("keeps the widest range", func(t *testing.T) {
+		expr := expression.FromD20("attack")
+		expr.ExpandCriticalRange(18, "Superior Critical")
+		expr.ExpandCriticalRange(19, "Improved Critical")
+
+		d20, ok := expr.Components[0].(*expression.D20Component)
+		require.True(t, ok)
+		assert.Equal(t, 18, d20.CriticalThreshold())
+		assert.Equal(t, "Superior Critical", d20.CriticalSource())
+	})
+
+	t.Run("natural 1 is never critical success", func(t *testing.T) {
+		expr := expression.FromD20("attack")
+		expr.ExpandCriticalRange(-5, "broken")
+		expr.Rng = newMockRoller(1)
+		expr.Evaluate()
+
+		assert.False(t, expr.IsCriticalSuccess())
+		assert.True(t, expr.IsCriticalFailure())
+	})
+
+	t.Run("survives cloning", func(t *testing.T) {
+		expr := expression.FromD20("attack")
+		expr.GiveAdvantage("help")
+		expr.ExpandCriticalRange(19, "Improved Critical")
+		clone := expr.Clone()
+		clone.Rng = newMockRoller(3, 19)
+		clone.Evaluate()
+
+		assert.Equal(t, 19, clone.Value)
+		assert.True(t, clone.IsCriticalSuccess())
+	})
+
+	t.Run("attack odds use the critical range", func(t *testing.T) {
+		expr := expression.FromD20("attack")
+		expr.ExpandCriticalRange(19, "Improved Critical")
+
+		odds := expr.AttackOdds(30)
+		assert.InDelta(t, 0.1, odds.Critical, 1e-9)
+		assert.InDelta(t, 0.1, odds.Hit, 1e-9)
+	})
+}
+
+func TestD20Component_Modifiers(t *testing.T) {
+	t.Run("rerolls a natural 1", func(t *testing.T) {
+		expr := expression.FromD20("attack")
+		expr.Rng = newMockRoller(1, 14)
+		expr.RerollBelow(2, "Lucky")
+		expr.Evaluate()
+
+		assert.Equal(t, 14, expr.Value)
+		assert.False(t, expr.IsCriticalFailure())
+		d20 := expr.Components[0].(*expression.D20Component)
+		assert.Equal(t, []int{1, 14}, d20.Rolls()[0].Faces)
+	})
+
+	t.Run("rerolls each die with advantage", func(t *testing.T) {
+		expr := expression.FromD20("attack")
+		expr.Rng = newMockRoller(1, 3, 1, 8)
+		expr.GiveAdvantage("test")
+		expr.RerollBelow(2, "Lucky")
+		expr.Evaluate()
+
+		assert.Equal(t, 8, expr.Value)
+	})
+
+	t.Run("minimum face", func(t *testing.T) {
+		expr := expression.FromD20("check")
+		expr.Rng = newMockRoller(4)
+		expr.MinimumFace(10, "Reliable Talent")
+		expr.Evaluate()
+
+		assert.Equal(t, 10, expr.Value)
+		assert.InDelta(t, 0.5, expr.Distribution().Probability(10), 1e-9)
+	})
+
+	t.Run("lucky lowers the chance of a natural 1", func(t *testing.T) {
+		expr := expression.FromD20("attack")
+		expr.RerollBelow(2, "Lucky")
+
+		assert.InDelta(t, 0.0025, expr.Distribution().Probability(1), 1e-9)
+	})
+}
@@ -1,6 +1,10 @@
 package expression
 
 import (
+	"fmt"
+	"math"
+	"slices"
+
 	"anvil/internal/mathi"
 	"anvil/internal/tag"
 )
@@ -11,6 +15,11 @@ type DiceComponent struct {
 	source     string
 	times      int
 	sides      int
+	keep       int
+	keepLowest bool
+	modifiers  []DiceModifier
+	rolls      []DieRoll
+	discarded  []int
 	tags       tag.Container
 	components []Component
 }
@@ -19,6 +28,12 @@ func newDiceComponent(times int, sides int, tags tag.Container, source string, c
 	return &DiceComponent{times: times, sides: sides, tags: tags, source: source, components: components}
 }
 
+func (c *DiceComponent) withKeep(keep int, lowest bool) *DiceComponent {
+	c.keep = keep
+	c.keepLowest = lowest
+	return c
+}
+
 func (c *DiceComponent) Kind() ComponentKind {
 	return ComponentKindDice
 }
@@ -43,6 +58,28 @@ func (c *DiceComponent) Sides() int {
 	return c.sides
 }
 
+// Keep returns how many dice count towards the total, 0 means all of them
+func (c *DiceComponent) Keep() int {
+	return c.keep
+}
+
+func (c *DiceComponent) KeepLowest() bool {
+	return c.keepLowest
+}
+
+func (c *DiceComponent) Notation() string {
+	notation := fmt.Sprintf("%dd%d", c.times, c.sides)
+	if c.keep == 0 {
+		return notation
+	}
+
+	if c.keepLowest {
+		return fmt.Sprintf("%skl%d", notation, c.keep)
+	}
+
+	return fmt.Sprintf("%skh%d", notation, c.keep)
+}
+
 func (c *DiceComponent) Components() []Component {
 	return c.components
 }
@@ -51,23 +88,170 @@ func (c *DiceComponent) Values() []int {
 	return c.values
 }
 
+// Rolls are the dice counted towards the value, with their faces before and after modifiers
+func (c *DiceComponent) Rolls() []DieRoll {
+	return c.rolls
+}
+
+// Discarded are the totals of the attempts a best-of modifier threw away
+func (c *DiceComponent) Discarded() []int {
+	return c.discarded
+}
+
+func (c *DiceComponent) Modifiers() []DiceModifier {
+	return c.modifiers
+}
+
+// RerollBelow rerolls every die that lands below threshold once, the new face must be used
+func (c *DiceComponent) RerollBelow(threshold int, source string) *DiceComponent {
+	c.modifiers = append(c.modifiers, DiceModifier{Kind: DiceModifierReroll, Value: threshold, Source: source})
+	return c
+}
+
+// MinimumFace treats every die that lands below minimum as minimum
+func (c *DiceComponent) MinimumFace(minimum int, source string) *DiceComponent {
+	c.modifiers = append(c.modifiers, DiceModifier{Kind: DiceModifierMinimum, Value: minimum, Source: source})
+	return c
+}
+
+// Explode rolls an extra die and adds it every time a die lands on its highest face
+func (c *DiceComponent) Explode(source string) *DiceComponent {
+	c.modifiers = append(c.modifiers, DiceModifier{Kind: DiceModifierExplode, Source: source})
+	return c
+}
+
+// BestOf rolls all the dice attempts times and keeps the best total
+func (c *DiceComponent) BestOf(attempts int, source string) *DiceComponent {
+	c.modifiers = append(c.modifiers, DiceModifier{Kind: DiceModifierBestOf, Value: attempts, Source: source})
+	return c
+}
+
 func (c *DiceComponent) Evaluate(ctx *Context) int {
-	c.values = []int{}
+	evaluateBestOf(ctx, []*DiceComponent{c}, rulesOf(c.modifiers).attempts)
+	return c.value
+}
+
+// evaluateBestOf rolls every dice component of group attempts times and keeps, for all of them, the attempt with the
+// best combined total
+func evaluateBestOf(ctx *Context, group []*DiceComponent, attempts int) {
+	best := 0
+	for _, c := range group {
+		c.discarded = []int{}
+	}
+
+	for attempt := range max(attempts, 1) {
+		rolls := make([][]DieRoll, len(group))
+		values := make([]int, len(group))
+		total := 0
+		for i, c := range group {
+			rolls[i] = c.roll(ctx, rulesOf(c.modifiers))
+			values[i] = mathi.Sum(c.kept(rollValues(rolls[i]))...) * mathi.Sign(c.times)
+			total += values[i]
+		}
+
+		if attempt > 0 && total <= best {
+			for i, c := range group {
+				c.discarded = append(c.discarded, values[i])
+			}
+			continue
+		}
+
+		for i, c := range group {
+			if attempt > 0 {
+				c.discarded = append(c.discarded, c.value)
+			}
+
+			c.rolls = rolls[i]
+			c.values = rollValues(rolls[i])
+			c.value = values[i]
+		}
+		best = total
+	}
+}
+
+// bestOf is the strongest best-of modifier of the dice, dice sharing its source are rolled as one group
+func (c *DiceComponent) bestOf() (DiceModifier, bool) {
+	best := DiceModifier{Kind: DiceModifierBestOf, Value: 1}
+	for _, modifier := range c.modifiers {
+		if modifier.Kind == DiceModifierBestOf && modifier.Value > best.Value {
+			best = modifier
+		}
+	}
+
+	return best, best.Value > 1
+}
+
+func (c *DiceComponent) roll(ctx *Context, rules dieRules) []DieRoll {
 	times := mathi.Abs(c.times)
+	rolls := make([]DieRoll, 0, times)
 	for i := 0; i < times; i++ {
-		c.values = append(c.values, ctx.Rng.Roll(c.sides))
+		rolls = append(rolls, rules.roll(ctx.Rng, c.sides))
 	}
 
-	c.value = mathi.Sum(c.values...) * mathi.Sign(c.times)
-	return c.value
+	return rolls
+}
+
+func rollValues(rolls []DieRoll) []int {
+	values := make([]int, len(rolls))
+	for i, roll := range rolls {
+		values[i] = roll.Value
+	}
+
+	return values
+}
+
+func (c *DiceComponent) kept(values []int) []int {
+	if c.keep == 0 || c.keep >= len(values) {
+		return values
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	if c.keepLowest {
+		return sorted[:c.keep]
+	}
+
+	return sorted[len(sorted)-c.keep:]
+}
+
+func (c *DiceComponent) keptCount() int {
+	if c.keep == 0 {
+		return mathi.Abs(c.times)
+	}
+
+	return mathi.Min(c.keep, mathi.Abs(c.times))
 }
 
 func (c *DiceComponent) Expected() int {
-	// Average of a die is (sides + 1) / 2
-	// For times dice: times * (sides + 1) / 2
 	absTimesDie := mathi.Abs(c.times)
-	expected := absTimesDie * (c.sides + 1) / 2
-	return expected * mathi.Sign(c.times)
+	if c.keptCount() == absTimesDie && len(c.modifiers) == 0 {
+		// Average of a die is (sides + 1) / 2
+		// For times dice: times * (sides + 1) / 2
+		expected := absTimesDie * (c.sides + 1) / 2
+		return expected * mathi.Sign(c.times)
+	}
+
+	return int(math.Round(c.Distribution().Mean()))
+}
+
+func (c *DiceComponent) Distribution() Distribution {
+	rules := rulesOf(c.modifiers)
+	if rules.attempts > 1 {
+		return c.attemptDistribution().bestOf(rules.attempts)
+	}
+
+	return c.attemptDistribution()
+}
+
+// attemptDistribution is the distribution of a single roll of the dice, before any best-of modifier
+func (c *DiceComponent) attemptDistribution() Distribution {
+	rules := rulesOf(c.modifiers)
+	result := keptDiceDistribution(mathi.Abs(c.times), rules.distribution(c.sides), c.keptCount(), c.keepLowest)
+	if c.times < 0 {
+		result = result.negate()
+	}
+
+	return result
 }
 
 func (c *DiceComponent) Clone() Component {
@@ -75,5 +259,14 @@ func (c *DiceComponent) Clone() Component {
 	for i, component := range c.components {
 		components[i] = component.Clone()
 	}
-	return &DiceComponent{times: c.times, sides: c.sides, tags: c.tags.Clone(), source: c.source, components: components}
+	return &DiceComponent{
+		times:      c.times,
+		sides:      c.sides,
+		keep:       c.keep,
+		keepLowest: c.keepLowest,
+		modifiers:  slices.Clone(c.modifiers),
+		tags:       c.tags.Clone(),
+		source:     c.source,
+		components: components,
+	}
 }
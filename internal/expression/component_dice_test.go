@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"anvil/internal/expression"
 	"anvil/internal/tag"
@@ -74,3 +75,180 @@ func TestDiceComponent_Evaluate(t *testing.T) {
 		assert.Equal(t, 8, diceComp.Sides())
 	})
 }
+
+func TestDiceComponent_Expected(t *testing.T) {
+	t.Run("averages every die", func(t *testing.T) {
+		expr := expression.FromDice(2, 6, "test")
+		assert.Equal(t, 7, expr.Expected())
+	})
+
+	t.Run("accounts for keeping the highest dice", func(t *testing.T) {
+		expr, err := expression.FromFormula("4d6kh3", "test")
+		require.NoError(t, err)
+		assert.Equal(t, 12, expr.Expected())
+	})
+
+	t.Run("accounts for keeping the lowest dice", func(t *testing.T) {
+		expr, err := expression.FromFormula("2d20kl1", "test")
+		require.NoError(t, err)
+		assert.Equal(t, 7, expr.Expected())
+	})
+}
+
+func TestDiceComponent_Modifiers(t *testing.T) {
+	t.Run("rerolls low faces once and keeps the new face", func(t *testing.T) {
+		expr := expression.FromDice(2, 6, "test")
+		expr.Rng = newMockRoller(2, 1, 5)
+		expr.RerollBelow(3, "Great Weapon Fighting")
+		expr.Evaluate()
+
+		assert.Equal(t, 6, expr.Value)
+		dice := expr.Components[0].(*expression.DiceComponent)
+		assert.Equal(t, expression.DieRoll{Original: 2, Value: 1, Faces: []int{2, 1}}, dice.Rolls()[0])
+		assert.Equal(t, expression.DieRoll{Original: 5, Value: 5, Faces: []int{5}}, dice.Rolls()[1])
+	})
+
+	t.Run("raises faces to the minimum", func(t *testing.T) {
+		expr := expression.FromDice(2, 6, "test")
+		expr.Rng = newMockRoller(1, 4)
+		expr.MinimumFace(3, "Great Weapon Fighting")
+		expr.Evaluate()
+
+		assert.Equal(t, 7, expr.Value)
+		dice := expr.Components[0].(*expression.DiceComponent)
+		assert.Equal(t, 1, dice.Rolls()[0].Original)
+		assert.Equal(t, 3, dice.Rolls()[0].Value)
+		assert.True(t, dice.Rolls()[0].IsAdjusted())
+		assert.False(t, dice.Rolls()[1].IsAdjusted())
+	})
+
+	t.Run("explodes on the highest face", func(t *testing.T) {
+		expr := expression.FromDice(1, 6, "test")
+		expr.Rng = newMockRoller(6, 6, 2)
+		expr.ExplodeDice("test")
+		expr.Evaluate()
+
+		assert.Equal(t, 14, expr.Value)
+		dice := expr.Components[0].(*expression.DiceComponent)
+		assert.Equal(t, []int{6, 6, 2}, dice.Rolls()[0].Faces)
+	})
+
+	t.Run("keeps the best of several attempts", func(t *testing.T) {
+		expr := expression.FromDice(2, 6, "test")
+		expr.Rng = newMockRoller(1, 2, 4, 5, 2, 2)
+		expr.BestOf(3, "Savage Attacker")
+		expr.Evaluate()
+
+		assert.Equal(t, 9, expr.Value)
+		dice := expr.Components[0].(*expression.DiceComponent)
+		assert.Equal(t, []int{4, 5}, dice.Values())
+		assert.Equal(t, []int{3, 4}, dice.Discarded())
+	})
+
+	t.Run("rolls every die of the expression as one group for best of", func(t *testing.T) {
+		expr, err := expression.FromFormula("1d8+1d6", "test")
+		require.NoError(t, err)
+		expr.Rng = newMockRoller(1, 6, 4, 2, 3)
+		expr.BestOf(2, "Savage Attacker")
+		require.NoError(t, expr.AddDamageFormula("1d4", tag.Container{}, "Sneak Attack"))
+		expr.Evaluate()
+
+		assert.Equal(t, 10, expr.Value)
+		first := expr.Components[0].(*expression.DiceComponent)
+		second := expr.Components[1].(*expression.DiceComponent)
+		assert.Equal(t, []int{1}, first.Values())
+		assert.Equal(t, []int{6}, second.Values())
+		assert.Equal(t, []int{4}, first.Discarded())
+		assert.Equal(t, []int{2}, second.Discarded())
+		assert.Equal(t, 3, expr.Components[2].Value())
+	})
+
+	t.Run("strongest modifier of a kind wins", func(t *testing.T) {
+		expr := expression.FromDice(1, 6, "test")
+		expr.Rng = newMockRoller(1)
+		expr.MinimumFace(2, "Elemental Adept")
+		expr.MinimumFace(3, "Great Weapon Fighting")
+		expr.Evaluate()
+
+		assert.Equal(t, 3, expr.Value)
+	})
+
+	t.Run("critical dice keep their modifiers", func(t *testing.T) {
+		expr := expression.FromDice(1, 6, "test")
+		expr.MinimumFace(3, "Great Weapon Fighting")
+		expr.DoubleDice("Critical")
+
+		dice := expr.Components[1].(*expression.DiceComponent)
+		assert.Len(t, dice.Modifiers(), 1)
+	})
+
+	t.Run("adjusted dice survive damage grouping", func(t *testing.T) {
+		expr := expression.FromDamageDice(1, 6, tag.ContainerFromString("damage.fire"), "test")
+		expr.Rng = newMockRoller(1)
+		expr.MinimumFace(2, "Elemental Adept")
+		expr.EvaluateDamage()
+
+		assert.Equal(t, 2, expr.Value)
+		require.Len(t, expr.Components[0].Components(), 1)
+		dice := expr.Components[0].Components()[0].(*expression.DiceComponent)
+		assert.Equal(t, 1, dice.Rolls()[0].Original)
+	})
+}
+
+func TestDiceComponent_ModifierDistribution(t *testing.T) {
+	t.Run("minimum face", func(t *testing.T) {
+		expr := expression.FromDice(1, 6, "test")
+		expr.MinimumFace(3, "test")
+		dist := expr.Distribution()
+
+		assert.Equal(t, 3, dist.Min())
+		assert.InDelta(t, 0.5, dist.Probability(3), 1e-9)
+		assert.InDelta(t, 4.0, dist.Mean(), 1e-9)
+	})
+
+	t.Run("reroll below", func(t *testing.T) {
+		expr := expression.FromDice(1, 6, "test")
+		expr.RerollBelow(2, "test")
+
+		assert.InDelta(t, 1.0/36.0, expr.Distribution().Probability(1), 1e-9)
+		assert.InDelta(t, 7.0/36.0, expr.Distribution().Probability(6), 1e-9)
+		assert.InDelta(t, 3.5+2.5/6.0, expr.Distribution().Mean(), 1e-9)
+	})
+
+	t.Run("exploding", func(t *testing.T) {
+		expr := expression.FromDice(1, 6, "test")
+		expr.ExplodeDice("test")
+		dist := expr.Distribution()
+
+		assert.InDelta(t, 0.0, dist.Probability(6), 1e-9)
+		assert.InDelta(t, 1.0/36.0, dist.Probability(7), 1e-9)
+		assert.InDelta(t, 4.2, dist.Mean(), 1e-6)
+	})
+
+	t.Run("best of two", func(t *testing.T) {
+		expr := expression.FromDice(1, 6, "test")
+		expr.BestOf(2, "test")
+
+		assert.InDelta(t, 161.0/36.0, expr.Distribution().Mean(), 1e-9)
+		assert.Equal(t, 4, expr.Expected())
+	})
+
+	t.Run("best of two across dice", func(t *testing.T) {
+		expr, err := expression.FromFormula("1d2+1d2", "test")
+		require.NoError(t, err)
+		expr.BestOf(2, "test")
+		dist := expr.Distribution()
+
+		assert.InDelta(t, 1.0/16.0, dist.Probability(2), 1e-9)
+		assert.InDelta(t, 7.0/16.0, dist.Probability(4), 1e-9)
+		assert.Equal(t, 3, expr.Expected())
+	})
+
+	t.Run("keeping dice with modifiers", func(t *testing.T) {
+		expr, err := expression.FromFormula("2d6kh1", "test")
+		require.NoError(t, err)
+		expr.MinimumFace(6, "test")
+
+		assert.InDelta(t, 1.0, expr.Distribution().Probability(6), 1e-9)
+	})
+}
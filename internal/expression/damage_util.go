@@ -4,6 +4,7 @@ import (
 	"anvil/internal/core/tags"
 	"anvil/internal/tag"
 	"fmt"
+	"slices"
 )
 
 func groupComponentsByTags(components []Component) [][]Component {
@@ -94,3 +95,20 @@ func buildMultiComponentSource(group []Component) string {
 	}
 	return firstSource
 }
+
+// adjustedDice keeps dice changed by modifiers around once a group is collapsed, so the faces can still be audited
+func adjustedDice(group []Component) []Component {
+	adjusted := make([]Component, 0)
+	for _, comp := range group {
+		dice, ok := comp.(*DiceComponent)
+		if !ok {
+			continue
+		}
+
+		if len(dice.Discarded()) > 0 || slices.ContainsFunc(dice.Rolls(), DieRoll.IsAdjusted) {
+			adjusted = append(adjusted, dice)
+		}
+	}
+
+	return adjusted
+}
@@ -0,0 +1,173 @@
+package expression
+
+import (
+	"anvil/internal/mathi"
+)
+
+// maxExplosions caps how many extra dice a single exploding die can chain into
+const maxExplosions = 10
+
+type DiceModifierKind string
+
+const (
+	DiceModifierReroll  DiceModifierKind = "reroll"
+	DiceModifierMinimum DiceModifierKind = "minimum"
+	DiceModifierExplode DiceModifierKind = "explode"
+	DiceModifierBestOf  DiceModifierKind = "best-of"
+)
+
+// DiceModifier changes individual dice after they are rolled, the strongest modifier of each kind wins
+type DiceModifier struct {
+	Kind   DiceModifierKind
+	Value  int
+	Source string
+}
+
+// DieRoll is a single die as it came off the table and the value it counts for after modifiers
+type DieRoll struct {
+	Original int
+	Value    int
+	Faces    []int
+}
+
+func (r DieRoll) IsAdjusted() bool {
+	return len(r.Faces) > 1 || r.Original != r.Value
+}
+
+type dieRules struct {
+	reroll   int
+	minimum  int
+	explode  bool
+	attempts int
+}
+
+func rulesOf(modifiers []DiceModifier) dieRules {
+	rules := dieRules{attempts: 1}
+	for _, modifier := range modifiers {
+		switch modifier.Kind {
+		case DiceModifierReroll:
+			rules.reroll = mathi.Max(rules.reroll, modifier.Value)
+		case DiceModifierMinimum:
+			rules.minimum = mathi.Max(rules.minimum, modifier.Value)
+		case DiceModifierExplode:
+			rules.explode = true
+		case DiceModifierBestOf:
+			rules.attempts = mathi.Max(rules.attempts, modifier.Value)
+		}
+	}
+
+	return rules
+}
+
+// roll rerolls a face below the reroll threshold once and keeps the new face, raises it to the
+// minimum and, when exploding, rolls another die on the highest face
+func (r dieRules) roll(rng Roller, sides int) DieRoll {
+	result := DieRoll{Original: rng.Roll(sides)}
+	face := result.Original
+	for explosions := 0; ; explosions++ {
+		result.Faces = append(result.Faces, face)
+		if face < r.reroll {
+			face = rng.Roll(sides)
+			result.Faces = append(result.Faces, face)
+		}
+
+		result.Value += mathi.Max(face, r.minimum)
+		if !r.explode || face != sides || explosions == maxExplosions {
+			return result
+		}
+
+		face = rng.Roll(sides)
+	}
+}
+
+// faceProbabilities are the chances of each natural face once rerolls are taken into account
+func (r dieRules) faceProbabilities(sides int) []float64 {
+	rerolled := float64(mathi.Clamp(r.reroll-1, 0, sides)) / float64(sides)
+	probabilities := make([]float64, sides)
+	for i := range probabilities {
+		face := i + 1
+		probabilities[i] = rerolled / float64(sides)
+		if face >= r.reroll {
+			probabilities[i] += 1 / float64(sides)
+		}
+	}
+
+	return probabilities
+}
+
+func (r dieRules) distribution(sides int) Distribution {
+	if sides < 1 {
+		return pointDistribution(0)
+	}
+
+	return r.explodingDistribution(sides, maxExplosions)
+}
+
+func (r dieRules) explodingDistribution(sides int, explosions int) Distribution {
+	exploding := r.explode && explosions > 0
+	maxValue := mathi.Max(sides, r.minimum)
+	chain := pointDistribution(0)
+	if exploding {
+		chain = r.explodingDistribution(sides, explosions-1)
+		maxValue += chain.Max()
+	}
+
+	probabilities := make([]float64, maxValue+1)
+	for i, p := range r.faceProbabilities(sides) {
+		face := i + 1
+		value := mathi.Max(face, r.minimum)
+		if face != sides || !exploding {
+			probabilities[value] += p
+			continue
+		}
+
+		for j, q := range chain.probabilities {
+			probabilities[value+chain.offset+j] += p * q
+		}
+	}
+
+	return newDistribution(0, probabilities)
+}
+
+// bestOfGroup are the dice components rolled together under the best-of modifiers of one source
+type bestOfGroup struct {
+	attempts int
+	dice     []*DiceComponent
+}
+
+// bestOfGroups collects the dice of components by the source of their best-of modifier
+func bestOfGroups(components []Component) map[string]*bestOfGroup {
+	groups := make(map[string]*bestOfGroup)
+	for _, component := range components {
+		dice, ok := component.(*DiceComponent)
+		if !ok {
+			continue
+		}
+
+		modifier, grouped := dice.bestOf()
+		if !grouped {
+			continue
+		}
+
+		group, exists := groups[modifier.Source]
+		if !exists {
+			group = &bestOfGroup{}
+			groups[modifier.Source] = group
+		}
+
+		group.attempts = mathi.Max(group.attempts, modifier.Value)
+		group.dice = append(group.dice, dice)
+	}
+
+	return groups
+}
+
+// distribution is the distribution of the best combined total out of the group's attempts
+func (g *bestOfGroup) distribution() Distribution {
+	result := pointDistribution(0)
+	for _, dice := range g.dice {
+		result = result.add(dice.attemptDistribution())
+	}
+
+	return result.bestOf(g.attempts)
+}
@@ -0,0 +1,158 @@
+package expression
+
+import "math"
+
+// Distribution is the exact discrete probability distribution of an integer outcome
+type Distribution struct {
+	offset        int
+	probabilities []float64
+}
+
+func pointDistribution(value int) Distribution {
+	return Distribution{offset: value, probabilities: []float64{1}}
+}
+
+func newDistribution(offset int, probabilities []float64) Distribution {
+	start := 0
+	for start < len(probabilities)-1 && probabilities[start] == 0 {
+		start++
+	}
+
+	end := len(probabilities)
+	for end > start+1 && probabilities[end-1] == 0 {
+		end--
+	}
+
+	return Distribution{offset: offset + start, probabilities: probabilities[start:end]}
+}
+
+func (d Distribution) Min() int {
+	return d.offset
+}
+
+func (d Distribution) Max() int {
+	return d.offset + len(d.probabilities) - 1
+}
+
+func (d Distribution) Probability(value int) float64 {
+	index := value - d.offset
+	if index < 0 || index >= len(d.probabilities) {
+		return 0
+	}
+
+	return d.probabilities[index]
+}
+
+func (d Distribution) AtLeast(value int) float64 {
+	total := 0.0
+	for i, p := range d.probabilities {
+		if d.offset+i >= value {
+			total += p
+		}
+	}
+
+	return total
+}
+
+func (d Distribution) AtMost(value int) float64 {
+	return 1 - d.AtLeast(value+1)
+}
+
+func (d Distribution) Mean() float64 {
+	total := 0.0
+	for i, p := range d.probabilities {
+		total += float64(d.offset+i) * p
+	}
+
+	return total
+}
+
+func (d Distribution) Variance() float64 {
+	mean := d.Mean()
+	total := 0.0
+	for i, p := range d.probabilities {
+		delta := float64(d.offset+i) - mean
+		total += delta * delta * p
+	}
+
+	return total
+}
+
+func (d Distribution) StandardDeviation() float64 {
+	return math.Sqrt(d.Variance())
+}
+
+// Percentile returns the smallest outcome whose cumulative probability reaches p (0 to 1)
+func (d Distribution) Percentile(p float64) int {
+	cumulative := 0.0
+	for i, probability := range d.probabilities {
+		cumulative += probability
+		if cumulative >= p-1e-9 {
+			return d.offset + i
+		}
+	}
+
+	return d.Max()
+}
+
+func (d Distribution) add(other Distribution) Distribution {
+	if len(d.probabilities) == 0 {
+		return other
+	}
+
+	if len(other.probabilities) == 0 {
+		return d
+	}
+
+	result := make([]float64, len(d.probabilities)+len(other.probabilities)-1)
+	for i, a := range d.probabilities {
+		for j, b := range other.probabilities {
+			result[i+j] += a * b
+		}
+	}
+
+	return newDistribution(d.offset+other.offset, result)
+}
+
+// bestOf is the distribution of the highest outcome out of n independent tries
+func (d Distribution) bestOf(n int) Distribution {
+	probabilities := make([]float64, len(d.probabilities))
+	below := 0.0
+	for i, p := range d.probabilities {
+		probabilities[i] = math.Pow(below+p, float64(n)) - math.Pow(below, float64(n))
+		below += p
+	}
+
+	return newDistribution(d.offset, probabilities)
+}
+
+func (d Distribution) negate() Distribution {
+	result := make([]float64, len(d.probabilities))
+	for i, p := range d.probabilities {
+		result[len(result)-1-i] = p
+	}
+
+	return newDistribution(-d.Max(), result)
+}
+
+func (e *Expression) Distribution() Distribution {
+	return distributionOf(e.Components)
+}
+
+func distributionOf(components []Component) Distribution {
+	result := pointDistribution(0)
+	groups := bestOfGroups(components)
+	for _, component := range components {
+		group := groupOf(groups, component)
+		if group == nil {
+			result = result.add(component.Distribution())
+			continue
+		}
+
+		if group.dice[0] == component {
+			result = result.add(group.distribution())
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,72 @@
+package expression
+
+import (
+	"math"
+
+	"anvil/internal/mathi"
+)
+
+func uniformDie(sides int) Distribution {
+	return dieRules{}.distribution(sides)
+}
+
+// keptDiceDistribution walks the faces from the best to the worst kept face, weighing the ways
+// to assign j of the remaining dice to each face while tracking how much the kept dice add up to
+func keptDiceDistribution(times int, die Distribution, keep int, lowest bool) Distribution {
+	if times == 0 {
+		return pointDistribution(0)
+	}
+
+	ways := newWaysTable(times, keep*die.Max())
+	ways[0][0] = 1
+	for i := range len(die.probabilities) {
+		face := die.Max() - i
+		if lowest {
+			face = die.Min() + i
+		}
+
+		ways = assignFace(ways, times, keep, face, die.Probability(face))
+	}
+
+	return newDistribution(0, ways[times])
+}
+
+func assignFace(ways [][]float64, times int, keep int, face int, chance float64) [][]float64 {
+	if chance == 0 {
+		return ways
+	}
+
+	next := newWaysTable(times, len(ways[0])-1)
+	for used, sums := range ways {
+		for sum, weight := range sums {
+			if weight == 0 {
+				continue
+			}
+
+			for j := 0; used+j <= times; j++ {
+				kept := mathi.Min(j, mathi.Max(0, keep-used))
+				next[used+j][sum+kept*face] += weight * binomial(times-used, j) * math.Pow(chance, float64(j))
+			}
+		}
+	}
+
+	return next
+}
+
+func newWaysTable(times int, maxSum int) [][]float64 {
+	table := make([][]float64, times+1)
+	for i := range table {
+		table[i] = make([]float64, maxSum+1)
+	}
+
+	return table
+}
+
+func binomial(n int, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+
+	return result
+}
@@ -0,0 +1,116 @@
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"anvil/internal/expression"
+)
+
+func TestExpression_Distribution(t *testing.T) {
+	t.Run("constant has a single outcome", func(t *testing.T) {
+		dist := expression.FromConstant(5, "test").Distribution()
+
+		assert.Equal(t, 5, dist.Min())
+		assert.Equal(t, 5, dist.Max())
+		assert.InDelta(t, 5.0, dist.Mean(), 1e-9)
+		assert.InDelta(t, 0.0, dist.Variance(), 1e-9)
+	})
+
+	t.Run("sums dice exactly", func(t *testing.T) {
+		dist := expression.FromDice(2, 6, "test").Distribution()
+
+		assert.Equal(t, 2, dist.Min())
+		assert.Equal(t, 12, dist.Max())
+		assert.InDelta(t, 6.0/36.0, dist.Probability(7), 1e-9)
+		assert.InDelta(t, 7.0, dist.Mean(), 1e-9)
+		assert.InDelta(t, 35.0/6.0, dist.Variance(), 1e-9)
+		assert.InDelta(t, 6.0/36.0, dist.AtLeast(10), 1e-9)
+		assert.InDelta(t, 3.0/36.0, dist.AtMost(3), 1e-9)
+	})
+
+	t.Run("combines dice and constants", func(t *testing.T) {
+		expr, err := expression.FromFormula("10-1d4", "test")
+		require.NoError(t, err)
+		dist := expr.Distribution()
+
+		assert.Equal(t, 6, dist.Min())
+		assert.Equal(t, 9, dist.Max())
+		assert.InDelta(t, 7.5, dist.Mean(), 1e-9)
+	})
+
+	t.Run("keeps highest dice", func(t *testing.T) {
+		expr, err := expression.FromFormula("4d6kh3", "test")
+		require.NoError(t, err)
+		dist := expr.Distribution()
+
+		assert.Equal(t, 3, dist.Min())
+		assert.Equal(t, 18, dist.Max())
+		assert.InDelta(t, 1.0/1296.0, dist.Probability(3), 1e-9)
+		assert.InDelta(t, 15869.0/1296.0, dist.Mean(), 1e-9)
+	})
+
+	t.Run("d20 with advantage or disadvantage", func(t *testing.T) {
+		advantage := expression.FromD20("test")
+		advantage.GiveAdvantage("test")
+		disadvantage := expression.FromD20("test")
+		disadvantage.GiveDisadvantage("test")
+
+		assert.InDelta(t, 13.825, advantage.Distribution().Mean(), 1e-9)
+		assert.InDelta(t, 39.0/400.0, advantage.Distribution().Probability(20), 1e-9)
+		assert.InDelta(t, 7.175, disadvantage.Distribution().Mean(), 1e-9)
+		assert.Equal(t, 14, advantage.Expected())
+		assert.Equal(t, 7, disadvantage.Expected())
+	})
+
+	t.Run("percentiles", func(t *testing.T) {
+		dist := expression.FromDice(2, 6, "test").Distribution()
+
+		assert.Equal(t, 2, dist.Percentile(0))
+		assert.Equal(t, 7, dist.Percentile(0.5))
+		assert.Equal(t, 12, dist.Percentile(1))
+	})
+}
+
+func TestExpression_AttackOdds(t *testing.T) {
+	newAttack := func() *expression.Expression {
+		expr := expression.FromD20("attack")
+		expr.AddConstant(5, "modifier")
+		return expr
+	}
+
+	t.Run("counts natural 20 and natural 1", func(t *testing.T) {
+		odds := newAttack().AttackOdds(15)
+
+		assert.InDelta(t, 0.55, odds.Hit, 1e-9)
+		assert.InDelta(t, 0.05, odds.Critical, 1e-9)
+		assert.InDelta(t, 0.05, odds.Fumble, 1e-9)
+	})
+
+	t.Run("natural 20 hits any armor class", func(t *testing.T) {
+		assert.InDelta(t, 0.05, newAttack().AttackOdds(30).Hit, 1e-9)
+	})
+
+	t.Run("natural 1 misses any armor class", func(t *testing.T) {
+		assert.InDelta(t, 0.95, newAttack().AttackOdds(2).Hit, 1e-9)
+	})
+
+	t.Run("expanded critical range", func(t *testing.T) {
+		expr := newAttack()
+		expr.ExpandCriticalRange(19, "test")
+		odds := expr.AttackOdds(30)
+
+		assert.InDelta(t, 0.10, odds.Critical, 1e-9)
+		assert.InDelta(t, 0.10, odds.Hit, 1e-9)
+	})
+
+	t.Run("expected damage includes critical dice", func(t *testing.T) {
+		damage, err := expression.FromFormula("1d6+3", "weapon")
+		require.NoError(t, err)
+
+		expected := newAttack().AttackOdds(15).ExpectedDamage(damage)
+		assert.InDelta(t, 0.5*6.5+0.05*10, expected, 1e-9)
+	})
+}
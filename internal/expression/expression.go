@@ -1,5 +1,8 @@
 package expression
 
+// fallbackRoller is only used by expressions evaluated without a roller, such as in isolated tests
+var fallbackRoller Roller = NewRngRoller()
+
 type Expression struct {
 	Value      int
 	Components []Component
@@ -8,13 +11,46 @@ type Expression struct {
 
 func (e *Expression) Evaluate() *Expression {
 	e.Value = 0
-	ctx := &Context{Rng: e.Rng}
+	ctx := &Context{Rng: e.roller()}
+	groups := bestOfGroups(e.Components)
 	for _, component := range e.Components {
-		e.Value += component.Evaluate(ctx)
+		group := groupOf(groups, component)
+		if group == nil {
+			e.Value += component.Evaluate(ctx)
+			continue
+		}
+
+		if group.dice[0] == component {
+			evaluateBestOf(ctx, group.dice, group.attempts)
+		}
+		e.Value += component.Value()
 	}
 	return e
 }
 
+// groupOf is the best-of group component is rolled with, nil when it is rolled on its own
+func groupOf(groups map[string]*bestOfGroup, component Component) *bestOfGroup {
+	dice, ok := component.(*DiceComponent)
+	if !ok {
+		return nil
+	}
+
+	modifier, grouped := dice.bestOf()
+	if !grouped {
+		return nil
+	}
+
+	return groups[modifier.Source]
+}
+
+func (e *Expression) roller() Roller {
+	if e.Rng == nil {
+		return fallbackRoller
+	}
+
+	return e.Rng
+}
+
 func (e *Expression) Clone() *Expression {
 	clone := *e
 	clone.Components = make([]Component, len(e.Components))
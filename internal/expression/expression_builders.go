@@ -6,31 +6,31 @@ import (
 )
 
 func FromConstant(value int, source string, components ...Component) *Expression {
-	expr := &Expression{Rng: NewRngRoller()}
+	expr := &Expression{}
 	expr.AddConstant(value, source, components...)
 	return expr
 }
 
 func FromDice(times int, sides int, source string, components ...Component) *Expression {
-	expr := &Expression{Rng: NewRngRoller()}
+	expr := &Expression{}
 	expr.AddDice(times, sides, source, components...)
 	return expr
 }
 
 func FromD20(source string, components ...Component) *Expression {
-	expr := &Expression{Rng: NewRngRoller()}
+	expr := &Expression{}
 	expr.AddD20(source, components...)
 	return expr
 }
 
 func FromDamageConstant(value int, tags tag.Container, source string, components ...Component) *Expression {
-	expr := &Expression{Rng: NewRngRoller()}
+	expr := &Expression{}
 	expr.AddDamageConstant(value, tags, source, components...)
 	return expr
 }
 
 func FromDamageDice(times int, sides int, tags tag.Container, source string, components ...Component) *Expression {
-	expr := &Expression{Rng: NewRngRoller()}
+	expr := &Expression{}
 	expr.AddDamageDice(times, sides, tags, source, components...)
 	return expr
 }
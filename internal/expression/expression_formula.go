@@ -0,0 +1,51 @@
+package expression
+
+import (
+	"anvil/internal/core/tags"
+	"anvil/internal/tag"
+)
+
+func FromFormula(formula string, source string) (*Expression, error) {
+	expr := &Expression{}
+	if err := expr.AddFormula(formula, source); err != nil {
+		return nil, err
+	}
+
+	return expr, nil
+}
+
+func FromDamageFormula(formula string, tags tag.Container, source string) (*Expression, error) {
+	expr := &Expression{}
+	if err := expr.AddDamageFormula(formula, tags, source); err != nil {
+		return nil, err
+	}
+
+	return expr, nil
+}
+
+func (e *Expression) AddFormula(formula string, source string) error {
+	return e.addFormula(formula, tag.ContainerFromTag(tags.Primary), source)
+}
+
+func (e *Expression) AddDamageFormula(formula string, tags tag.Container, source string) error {
+	return e.addFormula(formula, tags, source)
+}
+
+func (e *Expression) addFormula(formula string, tags tag.Container, source string) error {
+	terms, err := parseFormula(formula)
+	if err != nil {
+		return err
+	}
+
+	for _, term := range terms {
+		if !term.dice {
+			e.Components = append(e.Components, newConstantComponent(term.value, tags.Clone(), source))
+			continue
+		}
+
+		dice := newDiceComponent(term.times, term.sides, tags.Clone(), source)
+		e.Components = append(e.Components, dice.withKeep(term.keep, term.keepLowest))
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"anvil/internal/expression"
+	"anvil/internal/tag"
+)
+
+func TestFromFormula(t *testing.T) {
+	t.Run("parses dice plus constant", func(t *testing.T) {
+		expr, err := expression.FromFormula("2d6+3", "test")
+		require.NoError(t, err)
+		require.Len(t, expr.Components, 2)
+
+		dice, ok := expr.Components[0].(*expression.DiceComponent)
+		require.True(t, ok)
+		assert.Equal(t, 2, dice.Times())
+		assert.Equal(t, 6, dice.Sides())
+		assert.Equal(t, expression.ComponentKindConstant, expr.Components[1].Kind())
+		assert.Equal(t, 3, expr.Components[1].Value())
+
+		expr.Rng = newMockRoller(4, 5)
+		expr.Evaluate()
+		assert.Equal(t, 12, expr.Value)
+	})
+
+	t.Run("parses several dice groups and subtraction", func(t *testing.T) {
+		expr, err := expression.FromFormula("1d8 + 1d6 - 1", "test")
+		require.NoError(t, err)
+		require.Len(t, expr.Components, 3)
+
+		expr.Rng = newMockRoller(8, 6)
+		expr.Evaluate()
+		assert.Equal(t, 13, expr.Value)
+	})
+
+	t.Run("parses negative dice", func(t *testing.T) {
+		expr, err := expression.FromFormula("10-1d4", "test")
+		require.NoError(t, err)
+
+		expr.Rng = newMockRoller(3)
+		expr.Evaluate()
+		assert.Equal(t, 7, expr.Value)
+	})
+
+	t.Run("defaults to a single die", func(t *testing.T) {
+		expr, err := expression.FromFormula("d20", "test")
+		require.NoError(t, err)
+		require.Len(t, expr.Components, 1)
+
+		dice, ok := expr.Components[0].(*expression.DiceComponent)
+		require.True(t, ok)
+		assert.Equal(t, 1, dice.Times())
+		assert.Equal(t, 20, dice.Sides())
+	})
+
+	t.Run("keeps highest dice", func(t *testing.T) {
+		expr, err := expression.FromFormula("4d6kh3", "test")
+		require.NoError(t, err)
+
+		dice, ok := expr.Components[0].(*expression.DiceComponent)
+		require.True(t, ok)
+		assert.Equal(t, 3, dice.Keep())
+		assert.False(t, dice.KeepLowest())
+		assert.Equal(t, "4d6kh3", dice.Notation())
+
+		expr.Rng = newMockRoller(1, 5, 3, 6)
+		expr.Evaluate()
+		assert.Equal(t, 14, expr.Value)
+		assert.Equal(t, []int{1, 5, 3, 6}, dice.Values())
+	})
+
+	t.Run("keeps lowest dice", func(t *testing.T) {
+		expr, err := expression.FromFormula("2d20kl1", "test")
+		require.NoError(t, err)
+
+		expr.Rng = newMockRoller(17, 4)
+		expr.Evaluate()
+		assert.Equal(t, 4, expr.Value)
+	})
+}
+
+func TestFromDamageFormula(t *testing.T) {
+	t.Run("tags every component with the damage kind", func(t *testing.T) {
+		tags := tag.ContainerFromString("damage.kind.bludgeoning")
+		expr, err := expression.FromDamageFormula("1d6+1", tags, "slam")
+		require.NoError(t, err)
+		require.Len(t, expr.Components, 2)
+
+		for _, comp := range expr.Components {
+			compTags := comp.Tags()
+			assert.True(t, compTags.HasTag(tag.FromString("damage.kind.bludgeoning")))
+			assert.Equal(t, "slam", comp.Source())
+		}
+	})
+
+	t.Run("appends to an existing expression", func(t *testing.T) {
+		expr, err := expression.FromDamageFormula("1d8", tag.ContainerFromString("damage.kind.slashing"), "sword")
+		require.NoError(t, err)
+
+		err = expr.AddDamageFormula("1d6", tag.ContainerFromString("damage.kind.fire"), "sword")
+		require.NoError(t, err)
+		assert.Len(t, expr.Components, 2)
+		assert.True(t, expr.HasDamageType(tag.FromString("damage.kind.fire")))
+	})
+}
+
+func TestFormula_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula string
+		offset  int
+		err     error
+	}{
+		{name: "empty", formula: "  ", offset: 2, err: expression.ErrEmptyFormula},
+		{name: "missing sides", formula: "2d", offset: 2, err: expression.ErrUnexpectedEnd},
+		{name: "dangling operator", formula: "1d6+", offset: 4, err: expression.ErrUnexpectedEnd},
+		{name: "unknown character", formula: "1d6*2", offset: 3, err: expression.ErrUnexpectedCharacter},
+		{name: "zero dice", formula: "1d4+0d6", offset: 4, err: expression.ErrInvalidDice},
+		{name: "zero sides", formula: "1d0", offset: 0, err: expression.ErrInvalidDice},
+		{name: "keep too many", formula: "2d20kh3", offset: 6, err: expression.ErrInvalidKeep},
+		{name: "unknown keep mode", formula: "4d6kx3", offset: 4, err: expression.ErrUnexpectedCharacter},
+		{name: "number overflow", formula: "99999999999999999999", offset: 0, err: expression.ErrInvalidNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := expression.FromFormula(tt.formula, "test")
+			assert.Nil(t, expr)
+			require.ErrorIs(t, err, tt.err)
+
+			var parseErr *expression.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tt.offset, parseErr.Offset)
+			assert.Equal(t, tt.formula, parseErr.Formula)
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package expression
 
 import (
+	"anvil/internal/mathi"
 	"anvil/internal/tag"
 	"fmt"
+	"math"
+	"slices"
 )
 
 func (e *Expression) GiveAdvantage(source string) {
@@ -31,6 +34,61 @@ func (e *Expression) GiveDisadvantage(source string) {
 	d20.GiveDisadvantage(source)
 }
 
+func (e *Expression) ExpandCriticalRange(threshold int, source string) {
+	if len(e.Components) == 0 {
+		panic("no components to expand critical range of")
+	}
+	d20, ok := e.Components[0].(*D20Component)
+
+	if !ok {
+		panic("can only expand critical range of d20 components")
+	}
+
+	d20.ExpandCriticalRange(threshold, source)
+}
+
+// RerollBelow rerolls every d20 or damage die that lands below threshold once
+func (e *Expression) RerollBelow(threshold int, source string) {
+	for _, component := range e.Components {
+		switch c := component.(type) {
+		case *D20Component:
+			c.RerollBelow(threshold, source)
+		case *DiceComponent:
+			c.RerollBelow(threshold, source)
+		}
+	}
+}
+
+// MinimumFace treats every d20 or damage die that lands below minimum as minimum
+func (e *Expression) MinimumFace(minimum int, source string) {
+	for _, component := range e.Components {
+		switch c := component.(type) {
+		case *D20Component:
+			c.MinimumFace(minimum, source)
+		case *DiceComponent:
+			c.MinimumFace(minimum, source)
+		}
+	}
+}
+
+func (e *Expression) ExplodeDice(source string) {
+	for _, component := range e.Components {
+		if dice, ok := component.(*DiceComponent); ok {
+			dice.Explode(source)
+		}
+	}
+}
+
+// BestOf rolls every damage die of the expression attempts times as one group and keeps the attempt with the best
+// total, dice added afterwards are rolled on their own
+func (e *Expression) BestOf(attempts int, source string) {
+	for _, component := range e.Components {
+		if dice, ok := component.(*DiceComponent); ok {
+			dice.BestOf(attempts, source)
+		}
+	}
+}
+
 func (e *Expression) ReplaceWith(value int, source string, tags tag.Container) {
 	e.Components = []Component{newConstantComponent(value, tags, source)}
 }
@@ -44,7 +102,8 @@ func (e *Expression) DoubleDice(source string) {
 
 		diceSource := fmt.Sprintf("%s (%s)", dice.Source(), source)
 		newDice := newDiceComponent(dice.Times(), dice.Sides(), dice.Tags(), diceSource, dice.Components()...)
-		e.Components = append(e.Components, newDice)
+		newDice.modifiers = slices.Clone(dice.Modifiers())
+		e.Components = append(e.Components, newDice.withKeep(dice.Keep(), dice.KeepLowest()))
 	}
 }
 
@@ -56,11 +115,25 @@ func (e *Expression) MaxDice(source string) {
 		}
 
 		diceSource := fmt.Sprintf("%s (%s)", dice.Source(), source)
-		newDice := newConstantComponent(dice.Times()*dice.Sides(), dice.Tags(), diceSource)
+		maxValue := dice.keptCount() * dice.Sides() * mathi.Sign(dice.Times())
+		newDice := newConstantComponent(maxValue, dice.Tags(), diceSource)
 		e.Components = append(e.Components, newDice)
 	}
 }
 
+// HalveDamage halves the total of each damage type of an evaluated damage expression once, rounding down
+func (e *Expression) HalveDamage(source string) {
+	for _, group := range groupComponentsByTags(e.Components) {
+		value := 0
+		for _, component := range group {
+			value += component.Value()
+		}
+
+		groupTags := resolveGroupTags(group, e.Components)
+		e.Components = append(e.Components, newConstantComponent(value/2-value, groupTags, source))
+	}
+}
+
 func (e *Expression) EvaluateDamage() *Expression {
 	e.Evaluate()
 
@@ -82,7 +155,7 @@ func (e *Expression) EvaluateDamage() *Expression {
 			groupValue += comp.Value()
 		}
 
-		newComponents = append(newComponents, newConstantComponent(groupValue, groupTags, groupSource))
+		newComponents = append(newComponents, newConstantComponent(groupValue, groupTags, groupSource, adjustedDice(group)...))
 	}
 
 	e.Components = newComponents
@@ -108,6 +181,10 @@ func (e *Expression) IsCriticalFailure() bool {
 }
 
 func (e *Expression) Expected() int {
+	if len(bestOfGroups(e.Components)) > 0 {
+		return int(math.Round(e.Distribution().Mean()))
+	}
+
 	total := 0
 	for _, component := range e.Components {
 		total += component.Expected()
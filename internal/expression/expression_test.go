@@ -534,3 +534,29 @@ func findComponentByTag(expr *expression.Expression, targetTag tag.Tag) expressi
 	}
 	return nil
 }
+
+func TestExpression_HalveDamage(t *testing.T) {
+	expr := &expression.Expression{Rng: newMockRoller()}
+	expr.AddDamageConstant(9, tag.ContainerFromTag(tags.Fire), "burning hands")
+	expr.AddDamageConstant(4, tag.ContainerFromTag(tags.Thunder), "thunder")
+	damage := expr.EvaluateDamage()
+
+	damage.HalveDamage("Saved")
+	result := damage.EvaluateDamage()
+
+	assert.Equal(t, 6, result.Value)
+	assert.Equal(t, 4, findComponentByTag(result, tags.Fire).Value())
+	assert.Equal(t, 2, findComponentByTag(result, tags.Thunder).Value())
+}
+
+func TestExpression_HalveDamage_RoundsEachDamageTypeOnce(t *testing.T) {
+	expr := &expression.Expression{Rng: newMockRoller()}
+	expr.AddDamageConstant(3, tag.ContainerFromTag(tags.Fire), "burning hands")
+	expr.AddDamageConstant(3, tag.ContainerFromTag(tags.Fire), "upcast")
+	expr.Evaluate()
+
+	expr.HalveDamage("Saved")
+	result := expr.EvaluateDamage()
+
+	assert.Equal(t, 3, result.Value)
+}
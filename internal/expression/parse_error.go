@@ -0,0 +1,29 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrEmptyFormula        = errors.New("empty formula")
+	ErrUnexpectedCharacter = errors.New("unexpected character")
+	ErrUnexpectedEnd       = errors.New("unexpected end of formula")
+	ErrInvalidNumber       = errors.New("invalid number")
+	ErrInvalidDice         = errors.New("dice need at least one die with at least one side")
+	ErrInvalidKeep         = errors.New("can only keep between one and the number of dice rolled")
+)
+
+type ParseError struct {
+	Formula string
+	Offset  int
+	Err     error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid formula %q at offset %d: %v", e.Formula, e.Offset, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
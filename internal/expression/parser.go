@@ -0,0 +1,185 @@
+package expression
+
+import (
+	"strconv"
+	"unicode"
+)
+
+type formulaTerm struct {
+	value      int
+	times      int
+	sides      int
+	keep       int
+	keepLowest bool
+	dice       bool
+}
+
+type formulaParser struct {
+	input string
+	pos   int
+}
+
+// parseFormula accepts dice notation such as "2d6+3", "1d8+1d6-1", "4d6kh3" or "2d20kl1"
+func parseFormula(formula string) ([]formulaTerm, error) {
+	p := &formulaParser{input: formula}
+	p.skipSpace()
+	if p.done() {
+		return nil, p.fail(p.pos, ErrEmptyFormula)
+	}
+
+	sign := p.sign(1)
+	terms := []formulaTerm{}
+	for {
+		term, err := p.term(sign)
+		if err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, term)
+		p.skipSpace()
+		if p.done() {
+			return terms, nil
+		}
+
+		next := p.sign(0)
+		if next == 0 {
+			return nil, p.fail(p.pos, ErrUnexpectedCharacter)
+		}
+
+		sign = next
+	}
+}
+
+func (p *formulaParser) term(sign int) (formulaTerm, error) {
+	p.skipSpace()
+	start := p.pos
+	count, hasCount, err := p.number()
+	if err != nil {
+		return formulaTerm{}, err
+	}
+
+	if !p.accept('d') {
+		if !hasCount {
+			return formulaTerm{}, p.unexpected()
+		}
+
+		return formulaTerm{value: count * sign}, nil
+	}
+
+	if !hasCount {
+		count = 1
+	}
+
+	return p.dice(start, count, sign)
+}
+
+func (p *formulaParser) dice(start int, count int, sign int) (formulaTerm, error) {
+	sides, ok, err := p.number()
+	if err != nil {
+		return formulaTerm{}, err
+	}
+
+	if !ok {
+		return formulaTerm{}, p.unexpected()
+	}
+
+	if count < 1 || sides < 1 {
+		return formulaTerm{}, p.fail(start, ErrInvalidDice)
+	}
+
+	term := formulaTerm{times: count * sign, sides: sides, dice: true}
+	if !p.accept('k') {
+		return term, nil
+	}
+
+	return p.keep(term, count)
+}
+
+func (p *formulaParser) keep(term formulaTerm, count int) (formulaTerm, error) {
+	switch {
+	case p.accept('h'):
+	case p.accept('l'):
+		term.keepLowest = true
+	default:
+		return formulaTerm{}, p.unexpected()
+	}
+
+	start := p.pos
+	keep, ok, err := p.number()
+	if err != nil {
+		return formulaTerm{}, err
+	}
+
+	if !ok {
+		return formulaTerm{}, p.unexpected()
+	}
+
+	if keep < 1 || keep > count {
+		return formulaTerm{}, p.fail(start, ErrInvalidKeep)
+	}
+
+	term.keep = keep
+	return term, nil
+}
+
+func (p *formulaParser) number() (int, bool, error) {
+	start := p.pos
+	for !p.done() && unicode.IsDigit(rune(p.input[p.pos])) {
+		p.pos++
+	}
+
+	if start == p.pos {
+		return 0, false, nil
+	}
+
+	value, err := strconv.Atoi(p.input[start:p.pos])
+	if err != nil {
+		return 0, false, p.fail(start, ErrInvalidNumber)
+	}
+
+	return value, true, nil
+}
+
+func (p *formulaParser) sign(fallback int) int {
+	p.skipSpace()
+	if p.accept('+') {
+		return 1
+	}
+
+	if p.accept('-') {
+		return -1
+	}
+
+	return fallback
+}
+
+func (p *formulaParser) accept(c rune) bool {
+	if p.done() || unicode.ToLower(rune(p.input[p.pos])) != c {
+		return false
+	}
+
+	p.pos++
+	return true
+}
+
+func (p *formulaParser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *formulaParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *formulaParser) unexpected() error {
+	if p.done() {
+		return p.fail(p.pos, ErrUnexpectedEnd)
+	}
+
+	return p.fail(p.pos, ErrUnexpectedCharacter)
+}
+
+func (p *formulaParser) fail(offset int, err error) error {
+	return &ParseError{Formula: p.input, Offset: offset, Err: err}
+}
@@ -14,7 +14,11 @@ type RngRoller struct {
 }
 
 func NewRngRoller() *RngRoller {
-	source := rand.NewPCG(uint64(time.Now().UnixNano()), uint64(time.Now().UnixNano()))
+	return NewSeededRoller(uint64(time.Now().UnixNano()))
+}
+
+func NewSeededRoller(seed uint64) *RngRoller {
+	source := rand.NewPCG(seed, seed)
 	return &RngRoller{rng: rand.New(source)}
 }
 
@@ -61,3 +61,23 @@ func TestContext(t *testing.T) {
 		assert.Equal(t, 10, result)
 	})
 }
+
+func TestSeededRoller_Roll(t *testing.T) {
+	t.Run("same seed produces the same rolls", func(t *testing.T) {
+		a := expression.NewSeededRoller(42)
+		b := expression.NewSeededRoller(42)
+
+		for i := 0; i < 50; i++ {
+			assert.Equal(t, a.Roll(20), b.Roll(20))
+		}
+	})
+
+	t.Run("expressions use the injected roller", func(t *testing.T) {
+		first := expression.FromDice(4, 6, "test")
+		first.Rng = expression.NewSeededRoller(7)
+		second := expression.FromDice(4, 6, "test")
+		second.Rng = expression.NewSeededRoller(7)
+
+		assert.Equal(t, first.Evaluate().Value, second.Evaluate().Value)
+	})
+}
@@ -9,24 +9,43 @@ type MeleeActionDefinition struct {
 	DamageType    string
 }
 
+// RangedActionDefinition ranges are in grid squares like Reach, attacks beyond Range up to LongRange have disadvantage
 type RangedActionDefinition struct {
 	Name          string
 	Cost          map[string]int
 	Tags          []string
 	Range         int
+	LongRange     int
 	DamageFormula string
 	DamageType    string
 }
 
 type SpellActionDefinition struct {
-	Name          string
-	Cost          map[string]int
-	Tags          []string
-	Level         int
-	School        string
-	CastingTime   string
-	Range         string
-	Duration      string
-	DamageFormula string
-	DamageType    string
+	Name          string         `yaml:"name"`
+	Cost          map[string]int `yaml:"cost"`
+	Tags          []string       `yaml:"tags"`
+	Level         int            `yaml:"level"`
+	School        string         `yaml:"school"`
+	CastingTime   string         `yaml:"casting_time"`
+	Range         string         `yaml:"range"`
+	Duration      string         `yaml:"duration"`
+	Ritual        bool           `yaml:"ritual"`
+	Components    []string       `yaml:"components"`
+	DamageFormula string         `yaml:"damage_formula"`
+	DamageType    string         `yaml:"damage_type"`
+	// Upcast is added to the damage once per slot level above Level, for cantrips once per tier above the first
+	Upcast        string `yaml:"upcast"`
+	Save          string `yaml:"save"`
+	HalfOnSuccess bool   `yaml:"half_on_success"`
+	// Rider is an effect archetype added to every target that fails the save
+	Rider string         `yaml:"rider"`
+	Area  AreaDefinition `yaml:"area"`
+}
+
+// AreaDefinition describes an area of effect in feet, an empty shape targets a single creature
+type AreaDefinition struct {
+	Shape    string `yaml:"shape"`
+	Size     int    `yaml:"size"`
+	Width    int    `yaml:"width"`
+	FromSelf bool   `yaml:"from_self"`
 }
@@ -10,14 +10,16 @@ type AttributesDefinition struct {
 }
 
 type ProficienciesDefinition struct {
-	Skills []string
-	Bonus  int
+	Skills    []string
+	Expertise []string
+	Bonus     int
 }
 
 type ResourcesDefinition struct {
 	WalkSpeed  int
 	FlySpeed   int
 	SwimSpeed  int
+	Ammunition int
 	SpellSlot1 int
 	SpellSlot2 int
 	SpellSlot3 int
@@ -29,8 +31,15 @@ type ResourcesDefinition struct {
 	SpellSlot9 int
 }
 
+// SpellsDefinition lists spells by action archetype, prepared spells are known as well
+type SpellsDefinition struct {
+	Known    []string
+	Prepared []string
+}
+
 type ActorDefinition struct {
 	Name               string
+	Level              int
 	Team               string
 	HitPoints          int
 	MaxHitPoints       int
@@ -38,6 +47,10 @@ type ActorDefinition struct {
 	Attributes         AttributesDefinition
 	Proficiencies      ProficienciesDefinition
 	Resources          ResourcesDefinition
+	Spells             SpellsDefinition
+	Resistances        []string
+	Vulnerabilities    []string
+	Immunities         []string
 }
 
 // TODO: Add YAML loading functions like LoadActorFromFile, etc.
@@ -0,0 +1,47 @@
+package loader
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadSpellDefinition reads a single spell from a YAML file of fsys
+func LoadSpellDefinition(fsys fs.FS, file string) (SpellActionDefinition, error) {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return SpellActionDefinition{}, err
+	}
+
+	var def SpellActionDefinition
+	if err := yaml.Unmarshal(data, &def); err != nil {
+		return SpellActionDefinition{}, fmt.Errorf("invalid spell file '%s': %w", file, err)
+	}
+
+	return def, nil
+}
+
+// LoadSpellDefinitions reads every YAML file of dir in fsys, spells are keyed by archetype, the file name with
+// underscores turned into dashes
+func LoadSpellDefinitions(fsys fs.FS, dir string) (map[string]SpellActionDefinition, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.yml"))
+	if err != nil {
+		return nil, err
+	}
+
+	defs := make(map[string]SpellActionDefinition, len(files))
+	for _, file := range files {
+		def, err := LoadSpellDefinition(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		archetype := strings.ReplaceAll(strings.TrimSuffix(path.Base(file), ".yml"), "_", "-")
+		defs[archetype] = def
+	}
+
+	return defs, nil
+}
@@ -11,4 +11,7 @@ type WeaponDefinition struct {
 	Damage    []DamageData
 	Tags      []string
 	Reach     int
+	// Range and LongRange are in grid squares, weapons with a range can make ranged attacks
+	Range     int
+	LongRange int
 }
@@ -3,4 +3,5 @@ package loader
 type WorldDefinition struct {
 	Width  int
 	Height int
+	Seed   uint64
 }
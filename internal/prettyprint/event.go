@@ -14,10 +14,17 @@ import (
 var eventFormatters = map[string]EventFormatter{
 	eventbus.EventType(core.EncounterEvent{}):                 makeFormatter(printEncounter),
 	eventbus.EventType(core.RoundEvent{}):                     makeFormatter(printRound),
+	eventbus.EventType(core.InitiativeRollEvent{}):            makeFormatter(printInitiativeRoll),
+	eventbus.EventType(core.InitiativeEvent{}):                makeFormatter(printInitiative),
+	eventbus.EventType(core.OutcomeEvent{}):                   makeFormatter(printOutcome),
+	eventbus.EventType(core.JoinEncounterEvent{}):             makeFormatter(printJoinEncounter),
+	eventbus.EventType(core.LeaveEncounterEvent{}):            makeFormatter(printLeaveEncounter),
+	eventbus.EventType(core.RelationshipChangedEvent{}):       makeFormatter(printRelationshipChanged),
 	eventbus.EventType(core.TurnEvent{}):                      makeFormatter(printTurn),
 	eventbus.EventType(core.DeathEvent{}):                     makeFormatter(printDeath),
 	eventbus.EventType(core.UseActionEvent{}):                 makeFormatter(printUseAction),
 	eventbus.EventType(core.TakeDamageEvent{}):                makeFormatter(printTakeDamage),
+	eventbus.EventType(core.HealEvent{}):                      makeFormatter(printHeal),
 	eventbus.EventType(core.ExpressionResultEvent{}):          makeFormatter(printExpressionResult),
 	eventbus.EventType(core.CheckResultEvent{}):               makeFormatter(printCheckResult),
 	eventbus.EventType(core.AttackRollEvent{}):                makeFormatter(printAttackRoll),
@@ -25,10 +32,20 @@ var eventFormatters = map[string]EventFormatter{
 	eventbus.EventType(core.ConfirmEvent{}):                   makeFormatter(printConfirm),
 	eventbus.EventType(core.DamageRollEvent{}):                makeFormatter(printDamageRoll),
 	eventbus.EventType(core.EffectEvent{}):                    makeFormatter(printEffect),
+	eventbus.EventType(core.EffectExpiredEvent{}):             makeFormatter(printEffectExpired),
+	eventbus.EventType(core.ReactionEvent{}):                  makeFormatter(printReaction),
+	eventbus.EventType(core.AuraChangedEvent{}):               makeFormatter(printAuraChanged),
+	eventbus.EventType(core.ConcentrationEvent{}):             makeFormatter(printConcentration),
+	eventbus.EventType(core.ConcentrationBrokenEvent{}):       makeFormatter(printConcentrationBroken),
 	eventbus.EventType(core.AttributeChangeEvent{}):           makeFormatter(printAttributeChange),
 	eventbus.EventType(core.SavingThrowEvent{}):               makeFormatter(printSavingThrow),
+	eventbus.EventType(core.AbilityCheckEvent{}):              makeFormatter(printAbilityCheck),
+	eventbus.EventType(core.ContestedCheckEvent{}):            makeFormatter(printContestedCheck),
 	eventbus.EventType(core.SpendResourceEvent{}):             makeFormatter(printSpendResource),
 	eventbus.EventType(core.ConditionChangedEvent{}):          makeFormatter(printConditionChanged),
+	eventbus.EventType(core.ConditionLevelChangedEvent{}):     makeFormatter(printConditionLevelChanged),
+	eventbus.EventType(core.LongRestEvent{}):                  makeFormatter(printLongRest),
+	eventbus.EventType(core.CastSpellEvent{}):                 makeFormatter(printCastSpell),
 	eventbus.EventType(core.MoveEvent{}):                      makeFormatter(printMove),
 	eventbus.EventType(core.MoveStepEvent{}):                  makeFormatter(printMoveStep),
 	eventbus.EventType(core.DeathSavingThrowEvent{}):          makeFormatter(printDeathSavingThrow),
@@ -108,6 +125,9 @@ func printActor(a *core.Actor) string {
 		fmt.Sprintf("HP: %3d/%-3d", a.HitPoints, a.MaxHitPoints),
 		fmt.Sprintf("AC: %3d", a.ArmorClass().Value),
 	}
+	if a.TemporaryHitPoints > 0 {
+		stats = append(stats, fmt.Sprintf("THP: %3d", a.TemporaryHitPoints))
+	}
 	sb.WriteString(fmt.Sprintf("🧝 %-20s %s", a.Name, strings.Join(stats, " ")))
 	return sb.String()
 }
@@ -137,14 +157,18 @@ func printEncounter(e core.EncounterEvent) string {
 	// Add world section - no closure, world continues
 	tb.AddIndentedBlock(printWorld(e.World, []grid.Position{}))
 
-	// Add teams
+	// Add teams in the order they first appear so output is reproducible
 	teams := map[string][]*core.Actor{}
+	order := []string{}
 	for _, c := range e.Actors {
+		if _, ok := teams[string(c.Team)]; !ok {
+			order = append(order, string(c.Team))
+		}
 		teams[string(c.Team)] = append(teams[string(c.Team)], c)
 	}
 
-	for _, t := range teams {
-		tb.AddIndentedBlock(printTeam(t))
+	for _, name := range order {
+		tb.AddIndentedBlock(printTeam(teams[name]))
 		// Team closure should be indented under the team
 		tb.AddRawLine(TreeVertical + TreeEndCircle)
 	}
@@ -153,6 +177,47 @@ func printEncounter(e core.EncounterEvent) string {
 	return tb.String()
 }
 
+func printInitiativeRoll(e core.InitiativeRollEvent) string {
+	return fmt.Sprintf("⚡ %s rolls initiative", e.Source.Name)
+}
+
+func printInitiative(e core.InitiativeEvent) string {
+	lines := make([]string, len(e.Order))
+	for i, a := range e.Order {
+		lines[i] = fmt.Sprintf("%3d %s", e.Initiative[a], a.Name)
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("🔢 Initiative Order")
+	sb.WriteString("\n")
+	sb.WriteString(indent(strings.Join(lines, "\n"), 0))
+	return sb.String()
+}
+
+func printOutcome(e core.OutcomeEvent) string {
+	if len(e.Outcome.Winners) == 0 {
+		return fmt.Sprintf("🏳️ Nobody wins: %s", e.Outcome.Reason)
+	}
+
+	winners := make([]string, len(e.Outcome.Winners))
+	for i, w := range e.Outcome.Winners {
+		winners[i] = string(w)
+	}
+	return fmt.Sprintf("🏆 %s won: %s", strings.Join(winners, ", "), e.Outcome.Reason)
+}
+
+func printJoinEncounter(e core.JoinEncounterEvent) string {
+	return fmt.Sprintf("➕ %s joins the encounter", e.Actor.Name)
+}
+
+func printLeaveEncounter(e core.LeaveEncounterEvent) string {
+	return fmt.Sprintf("➖ %s leaves the encounter", e.Actor.Name)
+}
+
+func printRelationshipChanged(e core.RelationshipChangedEvent) string {
+	return fmt.Sprintf("🤝 %s and %s are now %s (was %s)", e.From, e.To, strings.ToLower(string(e.Relationship)), strings.ToLower(string(e.OldValue)))
+}
+
 func printRound(r core.RoundEvent) string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("🔄 Round %d", r.Round+1))
@@ -183,12 +248,24 @@ func printUseAction(u core.UseActionEvent) string {
 
 func printTakeDamage(d core.TakeDamageEvent) string {
 	sb := strings.Builder{}
-	sb.WriteString(fmt.Sprintf("🩸 %s takes %d damage (%d HP left)", d.Target.Name, d.Damage.Value, d.Target.HitPoints))
+	left := fmt.Sprintf("%d HP left", d.Target.HitPoints)
+	if d.TemporaryDamage > 0 {
+		left = fmt.Sprintf("%d absorbed by temporary HP, %s", d.TemporaryDamage, left)
+	}
+	sb.WriteString(fmt.Sprintf("🩸 %s takes %d damage (%s)", d.Target.Name, d.Damage.Value, left))
 	sb.WriteString("\n")
 	sb.WriteString(indent(printExpression(d.Damage, true), 1))
 	return sb.String()
 }
 
+func printHeal(h core.HealEvent) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("💚 %s heals %d hit points (%d HP left)", h.Target.Name, h.ActualHealing, h.Target.HitPoints))
+	sb.WriteString("\n")
+	sb.WriteString(indent(printExpression(h.Healing, true), 1))
+	return sb.String()
+}
+
 func printExpressionResult(e core.ExpressionResultEvent) string {
 	sb := strings.Builder{}
 	sb.WriteString("🎲 ")
@@ -245,6 +322,29 @@ func printEffect(e core.EffectEvent) string {
 	return fmt.Sprintf("⚡ %s triggered", e.Effect.Name)
 }
 
+func printEffectExpired(e core.EffectExpiredEvent) string {
+	return fmt.Sprintf("⌛ %s on %s expired", e.Effect.Name, e.Source.Name)
+}
+
+func printReaction(e core.ReactionEvent) string {
+	return fmt.Sprintf("↩️ %s reacts with %s", e.Source.Name, e.Reaction.Name)
+}
+
+func printAuraChanged(e core.AuraChangedEvent) string {
+	if e.Entered {
+		return fmt.Sprintf("🔆 %s enters %s", e.Actor.Name, e.Aura.Name)
+	}
+	return fmt.Sprintf("🔅 %s leaves %s", e.Actor.Name, e.Aura.Name)
+}
+
+func printConcentration(e core.ConcentrationEvent) string {
+	return fmt.Sprintf("🧘 %s concentrates on %s", e.Source.Name, e.Spell)
+}
+
+func printConcentrationBroken(e core.ConcentrationBrokenEvent) string {
+	return fmt.Sprintf("💢 %s loses concentration on %s: %s", e.Source.Name, e.Spell, e.Reason)
+}
+
 func printAttributeChange(e core.AttributeChangeEvent) string {
 	return fmt.Sprintf(
 		"🔀 %s %s changed from %d to %d",
@@ -264,6 +364,22 @@ func printSavingThrow(e core.SavingThrowEvent) string {
 	)
 }
 
+func printAbilityCheck(e core.AbilityCheckEvent) string {
+	check := tags.ToReadable(e.Attribute)
+	if !e.Skill.IsEmpty() {
+		check = fmt.Sprintf("%s (%s)", check, tags.ToReadable(e.Skill))
+	}
+
+	if e.DifficultyClass == 0 {
+		return fmt.Sprintf("🎲 %s rolls a %s check", e.Source.Name, check)
+	}
+	return fmt.Sprintf("🎲 %s rolls a %s check DC %d", e.Source.Name, check, e.DifficultyClass)
+}
+
+func printContestedCheck(e core.ContestedCheckEvent) string {
+	return fmt.Sprintf("⚖️ %s contests %s", e.Source.Name, e.Target.Name)
+}
+
 func printSpendResource(e core.SpendResourceEvent) string {
 	return fmt.Sprintf("🧾 %s spent %d %s", e.Source.Name, e.Amount, tags.ToReadable(e.Resource))
 }
@@ -284,6 +400,39 @@ func printConditionChanged(e core.ConditionChangedEvent) string {
 	return fmt.Sprintf("%s %s %s %s %s", emoji, e.Source.Name, text, tags.ToReadable(e.Condition), from)
 }
 
+func printConditionLevelChanged(e core.ConditionLevelChangedEvent) string {
+	emoji := "📈"
+	text := "rises"
+	if e.Level < e.OldLevel {
+		emoji = "📉"
+		text = "drops"
+	}
+
+	from := ""
+	if e.From != nil {
+		from = fmt.Sprintf(" from %s", e.From.Name)
+	}
+
+	return fmt.Sprintf("%s %s's %s %s to level %d%s", emoji, e.Source.Name, tags.ToReadable(e.Condition), text, e.Level, from)
+}
+
+func printLongRest(e core.LongRestEvent) string {
+	return fmt.Sprintf("🛌 %s takes a long rest", e.Source.Name)
+}
+
+func printCastSpell(e core.CastSpellEvent) string {
+	switch {
+	case e.Level == 0:
+		return fmt.Sprintf("✨ %s casts the cantrip %s", e.Source.Name, e.Spell)
+	case e.Slot == 0:
+		return fmt.Sprintf("✨ %s casts %s as a ritual", e.Source.Name, e.Spell)
+	case e.Slot > e.Level:
+		return fmt.Sprintf("✨ %s casts %s with a level %d slot, upcast from level %d", e.Source.Name, e.Spell, e.Slot, e.Level)
+	default:
+		return fmt.Sprintf("✨ %s casts %s with a level %d slot", e.Source.Name, e.Spell, e.Slot)
+	}
+}
+
 func printMove(e core.MoveEvent) string {
 	sb := strings.Builder{}
 	sb.WriteString(
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"anvil/internal/core"
+	"anvil/internal/expression"
 	"anvil/internal/grid"
 
 	"github.com/stretchr/testify/assert"
@@ -107,3 +108,19 @@ func TestFormatRollResult(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintTakeDamage(t *testing.T) {
+	target := &core.Actor{Name: "Cedric", HitPoints: 8}
+	damage := expression.FromConstant(7, "Zombie Slam")
+	damage.Evaluate()
+
+	t.Run("without temporary hit points", func(t *testing.T) {
+		result := printTakeDamage(core.TakeDamageEvent{Target: target, Damage: damage, ActualDamage: 7})
+		assert.Contains(t, result, "🩸 Cedric takes 7 damage (8 HP left)")
+	})
+
+	t.Run("with temporary hit points", func(t *testing.T) {
+		result := printTakeDamage(core.TakeDamageEvent{Target: target, Damage: damage, TemporaryDamage: 5, ActualDamage: 2})
+		assert.Contains(t, result, "🩸 Cedric takes 7 damage (5 absorbed by temporary HP, 8 HP left)")
+	})
+}
@@ -2,6 +2,7 @@ package prettyprint
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"anvil/internal/core/tags"
@@ -22,55 +23,34 @@ func printValue(value int, first bool) string {
 	return fmt.Sprintf("- %d", mathi.Abs(value))
 }
 
-func formatDiceRolls(component expression.Component) string {
-	var values []int
-	var times, sides int
+func formatRoll(roll expression.DieRoll) string {
+	if !roll.IsAdjusted() {
+		return fmt.Sprintf("%d", roll.Value)
+	}
 
-	// Type assert to get dice-specific methods
-	switch c := component.(type) {
-	case interface {
-		Values() []int
-		Times() int
-		Sides() int
-	}:
-		values = c.Values()
-		times = c.Times()
-		sides = c.Sides()
-	default:
-		return ""
+	faces := strings.Trim(strings.Join(strings.Fields(fmt.Sprint(roll.Faces)), ", "), "[]")
+	return fmt.Sprintf("%d (rolled %s)", roll.Value, faces)
+}
+
+func formatDiceRolls(formula string, rolls []expression.DieRoll) string {
+	if len(rolls) <= 1 && !slices.ContainsFunc(rolls, expression.DieRoll.IsAdjusted) {
+		return fmt.Sprintf(" (%s)", formula)
 	}
 
-	if len(values) <= 1 {
-		return ""
+	formatted := make([]string, len(rolls))
+	for i, roll := range rolls {
+		formatted[i] = formatRoll(roll)
 	}
 
-	rolls := strings.Trim(strings.Join(strings.Fields(fmt.Sprint(values)), ", "), "[]")
-	formula := fmt.Sprintf("%dd%d", times, sides)
-	return fmt.Sprintf(" (%s: %s)", formula, rolls)
+	return fmt.Sprintf(" (%s: %s)", formula, strings.Join(formatted, ", "))
 }
 
 func formatDice(component expression.Component) string {
-	if component.Kind() != expression.ComponentKindDice && component.Kind() != expression.ComponentKindD20 {
-		return ""
-	}
-
-	// Type assert to get dice-specific methods
 	switch c := component.(type) {
-	case interface {
-		Values() []int
-		Times() int
-		Sides() int
-	}:
-		if len(c.Values()) <= 1 {
-			return fmt.Sprintf(" (%dd%d)", c.Times(), c.Sides())
-		}
-		return formatDiceRolls(component)
-	case interface{ Values() []int }:
-		// D20 component - just show d20
-		if len(c.Values()) <= 1 {
-			return " (1d20)"
-		}
-		return formatDiceRolls(component)
+	case *expression.DiceComponent:
+		return formatDiceRolls(c.Notation(), c.Rolls())
+	case *expression.D20Component:
+		return formatDiceRolls(fmt.Sprintf("%dd20", mathi.Max(len(c.Rolls()), 1)), c.Rolls())
 	default:
 		return ""
 	}
@@ -83,46 +63,81 @@ func formatBranch(indent string, last bool) string {
 	return indent + TreeContinue
 }
 
-func formatAdvantageDisadvantage(component expression.Component, indent string, last bool) []string {
-	var advantages, disadvantages []string
+func formatDiceModifier(modifier expression.DiceModifier) string {
+	switch modifier.Kind {
+	case expression.DiceModifierReroll:
+		return fmt.Sprintf("Reroll Below %d: %s", modifier.Value, modifier.Source)
+	case expression.DiceModifierMinimum:
+		return fmt.Sprintf("Minimum Face %d: %s", modifier.Value, modifier.Source)
+	case expression.DiceModifierExplode:
+		return "Exploding: " + modifier.Source
+	case expression.DiceModifierBestOf:
+		return fmt.Sprintf("Best of %d: %s", modifier.Value, modifier.Source)
+	default:
+		return modifier.Source
+	}
+}
+
+func diceModifiers(dice *expression.DiceComponent) []string {
+	modifiers := make([]string, 0, len(dice.Modifiers())+1)
+	for _, modifier := range dice.Modifiers() {
+		modifiers = append(modifiers, formatDiceModifier(modifier))
+	}
 
-	// Type assert to get D20-specific methods
-	if d20, ok := component.(interface {
-		Advantage() []string
-		Disadvantage() []string
-	}); ok {
-		advantages = d20.Advantage()
-		disadvantages = d20.Disadvantage()
-	} else {
-		return nil
+	if len(dice.Discarded()) > 0 {
+		discarded := strings.Trim(strings.Join(strings.Fields(fmt.Sprint(dice.Discarded())), ", "), "[]")
+		modifiers = append(modifiers, "Discarded: "+discarded)
 	}
 
-	if len(advantages) == 0 && len(disadvantages) == 0 {
+	return modifiers
+}
+
+func componentModifiers(component expression.Component) []string {
+	switch c := component.(type) {
+	case *expression.D20Component:
+		return d20Modifiers(c)
+	case *expression.DiceComponent:
+		return diceModifiers(c)
+	default:
 		return nil
 	}
+}
 
-	formatted := make([]string, 0)
-	baseIndent := formatBranch(indent, last)
-	totalItems := len(advantages) + len(disadvantages)
+func d20Modifiers(d20 *expression.D20Component) []string {
+	modifiers := make([]string, 0, len(d20.Advantage())+len(d20.Disadvantage())+len(d20.Modifiers())+1)
+	for _, source := range d20.Advantage() {
+		modifiers = append(modifiers, "Advantage: "+source)
+	}
 
-	for idx, source := range advantages {
-		isLast := idx == totalItems-1
-		branch := TreeFork
-		if isLast {
-			branch = TreeEnd
-		}
-		formatted = append(formatted, fmt.Sprintf("\n%s%sAdvantage: %s", baseIndent, branch, source))
+	for _, source := range d20.Disadvantage() {
+		modifiers = append(modifiers, "Disadvantage: "+source)
+	}
+
+	if d20.CriticalSource() != "" {
+		modifiers = append(modifiers, fmt.Sprintf("Critical Range: %d-20 (%s)", d20.CriticalThreshold(), d20.CriticalSource()))
 	}
 
-	// Add disadvantages
-	for idx, source := range disadvantages {
-		globalIdx := len(advantages) + idx
-		isLast := globalIdx == totalItems-1
+	for _, modifier := range d20.Modifiers() {
+		modifiers = append(modifiers, formatDiceModifier(modifier))
+	}
+
+	return modifiers
+}
+
+func formatModifiers(component expression.Component, indent string, last bool) []string {
+	modifiers := componentModifiers(component)
+	if len(modifiers) == 0 {
+		return nil
+	}
+
+	formatted := make([]string, 0, len(modifiers))
+	baseIndent := formatBranch(indent, last)
+	for idx, modifier := range modifiers {
 		branch := TreeFork
-		if isLast {
+		if idx == len(modifiers)-1 {
 			branch = TreeEnd
 		}
-		formatted = append(formatted, fmt.Sprintf("\n%s%sDisadvantage: %s", baseIndent, branch, source))
+		formatted = append(formatted, fmt.Sprintf("\n%s%s%s", baseIndent, branch, modifier))
 	}
 
 	return formatted
@@ -144,11 +159,9 @@ func buildComponentSource(component expression.Component, indent string, last bo
 	source := strings.Builder{}
 	source.WriteString(component.Source())
 
-	if component.Kind() == expression.ComponentKindD20 {
-		advDisadv := formatAdvantageDisadvantage(component, indent, last)
-		if len(advDisadv) > 0 {
-			source.WriteString(strings.Join(advDisadv, ""))
-		}
+	modifiers := formatModifiers(component, indent, last)
+	if len(modifiers) > 0 {
+		source.WriteString(strings.Join(modifiers, ""))
 	}
 
 	compTags := component.Tags()
@@ -0,0 +1,49 @@
+package prettyprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/expression"
+)
+
+func TestPrintExpression_D20Modifiers(t *testing.T) {
+	t.Run("lists advantage and critical range", func(t *testing.T) {
+		expr := expression.FromD20("Base")
+		expr.GiveAdvantage("Reckless Attack")
+		expr.ExpandCriticalRange(19, "Improved Critical")
+
+		result := printExpression(expr)
+
+		assert.Contains(t, result, "Advantage: Reckless Attack")
+		assert.Contains(t, result, "Critical Range: 19-20 (Improved Critical)")
+	})
+
+	t.Run("omits critical range when unchanged", func(t *testing.T) {
+		result := printExpression(expression.FromD20("Base"))
+
+		assert.NotContains(t, result, "Critical Range")
+	})
+}
+
+func TestPrintExpression_DiceModifiers(t *testing.T) {
+	t.Run("shows original and adjusted faces", func(t *testing.T) {
+		expr := expression.FromDice(2, 6, "Greatsword")
+		expr.Rng = expression.NewSeededRoller(1)
+		expr.MinimumFace(7, "Great Weapon Fighting")
+		expr.Evaluate()
+
+		result := printExpression(expr)
+
+		assert.Contains(t, result, "= 14 (2d6: 7 (rolled ")
+		assert.Contains(t, result, "Minimum Face 7: Great Weapon Fighting")
+	})
+
+	t.Run("lists d20 rerolls", func(t *testing.T) {
+		expr := expression.FromD20("Base")
+		expr.RerollBelow(2, "Lucky")
+
+		assert.Contains(t, printExpression(expr), "Reroll Below 2: Lucky")
+	})
+}
@@ -30,6 +30,7 @@ func shouldPrintEnd() bool {
 		eventbus.EventType(core.ConditionChangedEvent{}),
 		eventbus.EventType(core.DeathSavingThrowResultEvent{}),
 		eventbus.EventType(core.TargetEvent{}),
+		eventbus.EventType(core.CastSpellEvent{}),
 	}
 
 	lastEvent := eventStack[len(eventStack)-1]
@@ -1,6 +1,7 @@
 package basic
 
 import (
+	"fmt"
 	"slices"
 
 	"anvil/internal/core"
@@ -23,6 +24,7 @@ type MeleeAction struct {
 	cost         map[tag.Tag]int
 	reach        int
 	damageSource core.DamageSource
+	reactions    []*core.Reaction
 }
 
 func NewMeleeAction(owner *core.Actor, name string, damageSource core.DamageSource, reach int, actionTags tag.Container, cost map[tag.Tag]int) *MeleeAction {
@@ -51,10 +53,12 @@ func NewMeleeActionFromDefinition(owner *core.Actor, def loader.MeleeActionDefin
 		actionTags.Add(tag.ContainerFromTag(tag.FromString(tagStr)))
 	}
 
-	// For now, only support simple "1d6" format - will add parser later
-	damageExpr := expression.FromDamageDice(1, 6, tag.ContainerFromTag(tag.FromString(def.DamageType)), def.Name)
+	damageType := tags.DamageKindFromString(def.DamageType)
+	damageExpr, err := expression.FromDamageFormula(def.DamageFormula, tag.ContainerFromTag(damageType), def.Name)
+	if err != nil {
+		panic(fmt.Sprintf("invalid damage formula '%s' for action '%s': %v", def.DamageFormula, def.Name, err))
+	}
 
-	damageType := tag.FromString(def.DamageType)
 	damageSource := core.NewDamageSource(*damageExpr, tag.ContainerFromTag(damageType))
 
 	a := &MeleeAction{
@@ -115,6 +119,10 @@ func (a *MeleeAction) Perform(pos []grid.Position) {
 	a.owner.Dispatcher.Emit(core.TargetEvent{Target: []*core.Actor{target}})
 	defer a.owner.Dispatcher.End()
 	a.Commit()
+	a.strike(target)
+}
+
+func (a *MeleeAction) strike(target *core.Actor) {
 	result := a.owner.AttackRoll(target, *a.Tags())
 	if result.Success {
 		dmg := a.owner.DamageRoll(a, result.Critical)
@@ -122,6 +130,13 @@ func (a *MeleeAction) Perform(pos []grid.Position) {
 	}
 }
 
+func (a *MeleeAction) Reactions() []*core.Reaction {
+	if a.reactions == nil {
+		a.reactions = []*core.Reaction{NewOpportunityAttackReaction(a)}
+	}
+	return a.reactions
+}
+
 func (a *MeleeAction) ValidPositions(from grid.Position) []grid.Position {
 	if !a.CanAfford() {
 		return []grid.Position{}
@@ -148,7 +163,7 @@ func (a *MeleeAction) ValidPositions(from grid.Position) []grid.Position {
 			continue
 		}
 
-		if other.IsDead() {
+		if other.IsDead() || !a.owner.CanAttack(other) {
 			continue
 		}
 
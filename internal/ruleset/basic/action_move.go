@@ -97,6 +97,9 @@ func (a MoveAction) Perform(pos []grid.Position) {
 	for _, node := range positions[1:] {
 		src.ConsumeResource(tags.ResourceWalkSpeed, 1)
 		src.Move(node, a)
+		if src.Position != node {
+			return
+		}
 	}
 }
 
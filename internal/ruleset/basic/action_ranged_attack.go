@@ -0,0 +1,172 @@
+package basic
+
+import (
+	"fmt"
+
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+	"anvil/internal/expression"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+	"anvil/internal/tag"
+
+	"github.com/google/uuid"
+)
+
+// RangedAction attacks a creature in line of sight up to its long range, ranges are in grid squares measured with
+// grid.Position.Distance like melee reach
+type RangedAction struct {
+	owner        *core.Actor
+	archetype    string
+	id           string
+	name         string
+	tags         tag.Container
+	cost         map[tag.Tag]int
+	normalRange  int
+	longRange    int
+	damageSource core.DamageSource
+	// thrown is the weapon that leaves the owner's hands with the attack
+	thrown core.Item
+}
+
+func NewRangedAction(owner *core.Actor, name string, damageSource core.DamageSource, normalRange int, longRange int, actionTags tag.Container, cost map[tag.Tag]int) *RangedAction {
+	a := &RangedAction{
+		owner:        owner,
+		archetype:    "ranged",
+		id:           uuid.New().String(),
+		name:         name,
+		tags:         actionTags,
+		cost:         cost,
+		normalRange:  normalRange,
+		longRange:    max(longRange, normalRange),
+		damageSource: damageSource,
+	}
+	a.tags.RemoveTag(tags.Melee)
+	a.tags.Add(tag.ContainerFromTag(tags.Attack, tags.Ranged))
+	return a
+}
+
+func NewRangedActionFromDefinition(owner *core.Actor, def loader.RangedActionDefinition) *RangedAction {
+	cost := make(map[tag.Tag]int)
+	for key, value := range def.Cost {
+		cost[tag.FromString(key)] = value
+	}
+
+	actionTags := tag.ContainerFromTag()
+	for _, tagStr := range def.Tags {
+		actionTags.Add(tag.ContainerFromTag(tag.FromString(tagStr)))
+	}
+
+	damageType := tags.DamageKindFromString(def.DamageType)
+	damageExpr, err := expression.FromDamageFormula(def.DamageFormula, tag.ContainerFromTag(damageType), def.Name)
+	if err != nil {
+		panic(fmt.Sprintf("invalid damage formula '%s' for action '%s': %v", def.DamageFormula, def.Name, err))
+	}
+
+	damageSource := core.NewDamageSource(*damageExpr, tag.ContainerFromTag(damageType))
+	return NewRangedAction(owner, def.Name, damageSource, def.Range, def.LongRange, actionTags, cost)
+}
+
+func (a *RangedAction) Owner() *core.Actor {
+	return a.owner
+}
+
+func (a *RangedAction) Archetype() string {
+	return a.archetype
+}
+
+func (a *RangedAction) ID() string {
+	return a.id
+}
+
+func (a *RangedAction) Name() string {
+	return a.name
+}
+
+func (a *RangedAction) Range() (int, int) {
+	return a.normalRange, a.longRange
+}
+
+func (a *RangedAction) Cost() map[tag.Tag]int {
+	return a.cost
+}
+
+func (a *RangedAction) CanAfford() bool {
+	return a.owner.Resources.CanAfford(a.cost)
+}
+
+func (a *RangedAction) Commit() {
+	if !a.CanAfford() {
+		panic("Attempt to commit action without affording cost")
+	}
+
+	for tag, amount := range a.cost {
+		a.owner.ConsumeResource(tag, amount)
+	}
+}
+
+func (a *RangedAction) Perform(pos []grid.Position) {
+	target := a.owner.World.ActorAt(pos[0])
+	a.owner.Dispatcher.Begin(core.UseActionEvent{Action: a, Source: a.owner, Target: pos})
+	a.owner.Dispatcher.Emit(core.TargetEvent{Target: []*core.Actor{target}})
+	defer a.owner.Dispatcher.End()
+	a.Commit()
+
+	attackTags := *a.Tags()
+	if a.owner.Position.Distance(target.Position) > a.normalRange {
+		attackTags.AddTag(tags.LongRange)
+	}
+
+	result := a.owner.AttackRoll(target, attackTags)
+	if result.Success {
+		dmg := a.owner.DamageRoll(a, result.Critical)
+		target.TakeDamage(*dmg)
+	}
+
+	if a.thrown != nil {
+		a.owner.Unequip(a.thrown)
+	}
+}
+
+func (a *RangedAction) ValidPositions(from grid.Position) []grid.Position {
+	if !a.CanAfford() {
+		return []grid.Position{}
+	}
+
+	valid := make([]grid.Position, 0)
+	for _, other := range a.owner.Enemies() {
+		pos := other.Position
+		if pos == from || from.Distance(pos) > a.longRange {
+			continue
+		}
+
+		if other.IsDead() || !a.owner.CanAttack(other) {
+			continue
+		}
+
+		if !a.owner.World.HasLineOfSight(from, pos) {
+			continue
+		}
+
+		valid = append(valid, pos)
+	}
+	return valid
+}
+
+func (a *RangedAction) AffectedPositions(tar []grid.Position) []grid.Position {
+	return []grid.Position{tar[0]}
+}
+
+func (a *RangedAction) Damage() *expression.Expression {
+	return a.damageSource.Damage()
+}
+
+func (a *RangedAction) Tags() *tag.Container {
+	combined := a.tags.Clone()
+	combined.Add(*a.damageSource.Tags())
+	return &combined
+}
+
+func (a *RangedAction) AverageDamage() int {
+	return a.Damage().Expected()
+}
@@ -0,0 +1,275 @@
+package basic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"anvil/internal/core"
+	"anvil/internal/core/shapes"
+	"anvil/internal/core/tags"
+	"anvil/internal/expression"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+	"anvil/internal/tag"
+
+	"github.com/google/uuid"
+)
+
+// SaveSpellAction makes every creature in its area roll a saving throw against the caster's spell save DC,
+// damage is rolled once and shared by all targets
+type SaveSpellAction struct {
+	spell
+	owner         *core.Actor
+	archetype     string
+	id            string
+	name          string
+	tags          tag.Container
+	cost          map[tag.Tag]int
+	castRange     int
+	area          shapes.Area
+	save          tag.Tag
+	halfOnSuccess bool
+	baseDamage    core.DamageSource
+	rider         func() *core.Effect
+}
+
+// NewSaveSpellActionFromDefinition builds the action, rider creates the effect added to targets failing the save
+func NewSaveSpellActionFromDefinition(owner *core.Actor, def loader.SpellActionDefinition, rider func() *core.Effect) *SaveSpellAction {
+	if def.Save == "" {
+		panic(fmt.Sprintf("spell '%s' has no saving throw", def.Name))
+	}
+
+	cost := make(map[tag.Tag]int)
+	for key, value := range def.Cost {
+		cost[tag.FromString(key)] = value
+	}
+
+	actionTags := spellTags(def)
+	actionTags.AddTag(tags.SpellSave)
+
+	a := &SaveSpellAction{
+		spell:         newSpell(def),
+		owner:         owner,
+		archetype:     "save-spell",
+		id:            uuid.New().String(),
+		name:          def.Name,
+		tags:          actionTags,
+		cost:          cost,
+		castRange:     parseRange(def.Range),
+		save:          tag.FromString("Actor.Attribute." + def.Save),
+		halfOnSuccess: def.HalfOnSuccess,
+		rider:         rider,
+		area: shapes.Area{
+			Kind:     shapes.AreaKind(strings.ToLower(def.Area.Shape)),
+			Size:     def.Area.Size,
+			Width:    def.Area.Width,
+			FromSelf: def.Area.FromSelf,
+		},
+	}
+
+	if def.DamageFormula != "" {
+		damageType := tags.DamageKindFromString(def.DamageType)
+		damageExpr, err := expression.FromDamageFormula(def.DamageFormula, tag.ContainerFromTag(damageType), def.Name)
+		if err != nil {
+			panic(fmt.Sprintf("invalid damage formula '%s' for spell '%s': %v", def.DamageFormula, def.Name, err))
+		}
+		a.baseDamage = core.NewDamageSource(*damageExpr, tag.ContainerFromTag(damageType))
+	}
+
+	return a
+}
+
+// parseRange turns a spell range such as "Self", "Touch" or "60 feet" into grid squares
+func parseRange(r string) int {
+	fields := strings.Fields(strings.ToLower(r))
+	if len(fields) == 0 || fields[0] == "self" {
+		return 0
+	}
+
+	if fields[0] == "touch" {
+		return 1
+	}
+
+	feet, err := strconv.Atoi(fields[0])
+	if err != nil {
+		panic(fmt.Sprintf("invalid spell range '%s'", r))
+	}
+
+	return feet / shapes.FeetPerCell
+}
+
+func (a *SaveSpellAction) Owner() *core.Actor {
+	return a.owner
+}
+
+func (a *SaveSpellAction) Archetype() string {
+	return a.archetype
+}
+
+func (a *SaveSpellAction) ID() string {
+	return a.id
+}
+
+func (a *SaveSpellAction) Name() string {
+	return a.name
+}
+
+func (a *SaveSpellAction) Tags() *tag.Container {
+	combined := a.tags.Clone()
+	if a.baseDamage != nil {
+		combined.Add(*a.baseDamage.Tags())
+	}
+	return &combined
+}
+
+func (a *SaveSpellAction) Cost() map[tag.Tag]int {
+	return a.cost
+}
+
+func (a *SaveSpellAction) CanAfford() bool {
+	return a.owner.Resources.CanAfford(a.cost) && a.hasSlot(a.owner)
+}
+
+func (a *SaveSpellAction) Commit() {
+	if !a.CanAfford() {
+		panic("Attempt to commit action without affording cost")
+	}
+
+	for tag, amount := range a.cost {
+		a.owner.ConsumeResource(tag, amount)
+	}
+}
+
+func (a *SaveSpellAction) Perform(pos []grid.Position) {
+	targets := make([]*core.Actor, 0)
+	for _, p := range a.AffectedPositions(pos) {
+		if target := a.owner.World.ActorAt(p); target != nil && !target.IsDead() {
+			targets = append(targets, target)
+		}
+	}
+
+	a.owner.Dispatcher.Begin(core.UseActionEvent{Action: a, Source: a.owner, Target: pos})
+	a.owner.Dispatcher.Emit(core.TargetEvent{Target: targets})
+	defer a.owner.Dispatcher.End()
+	slot := a.chooseSlot(a.owner)
+	a.Commit()
+	a.owner.CastSpell(a.name, a.level, slot)
+
+	var damage *expression.Expression
+	if a.baseDamage != nil {
+		damage = a.owner.DamageRoll(castDamage{SaveSpellAction: a, slot: slot}, false)
+	}
+
+	dc := a.owner.SpellSaveDC()
+	for _, target := range targets {
+		a.resolve(target, dc, damage)
+	}
+}
+
+func (a *SaveSpellAction) resolve(target *core.Actor, dc int, damage *expression.Expression) {
+	result := target.SaveThrow(a.save, dc)
+	if !result.Success {
+		if damage != nil {
+			target.TakeDamage(*damage)
+		}
+		if a.rider != nil {
+			target.AddEffect(a.rider())
+		}
+		return
+	}
+
+	if damage != nil && a.halfOnSuccess {
+		half := expression.FromDamageResult(*damage)
+		half.HalveDamage("Successful Save")
+		target.TakeDamage(*half)
+	}
+}
+
+func (a *SaveSpellAction) ValidPositions(from grid.Position) []grid.Position {
+	if !a.CanAfford() {
+		return []grid.Position{}
+	}
+
+	switch a.area.Kind {
+	case "":
+		return a.creaturesInRange(from)
+	case shapes.AreaEmanation:
+		return []grid.Position{from}
+	case shapes.AreaCone, shapes.AreaLine:
+		return a.aimFrom(from)
+	case shapes.AreaCube:
+		if a.area.FromSelf {
+			return a.aimFrom(from)
+		}
+	}
+
+	if a.area.FromSelf {
+		return []grid.Position{from}
+	}
+
+	return a.squaresInRange(from, a.castRange, func(pos grid.Position) bool { return a.owner.World.HasLineOfSight(from, pos) })
+}
+
+// aimFrom lists the squares an area coming from the caster can be pointed at
+func (a *SaveSpellAction) aimFrom(from grid.Position) []grid.Position {
+	return a.squaresInRange(from, max(a.area.Size/shapes.FeetPerCell, 1), func(pos grid.Position) bool { return pos != from })
+}
+
+// squaresInRange lists the open squares kept by keep within radius of from, diagonal squares counting as one
+func (a *SaveSpellAction) squaresInRange(from grid.Position, radius int, keep func(grid.Position) bool) []grid.Position {
+	valid := make([]grid.Position, 0)
+	for y := from.Y - radius; y <= from.Y+radius; y++ {
+		for x := from.X - radius; x <= from.X+radius; x++ {
+			pos := grid.Position{X: x, Y: y}
+			if !a.owner.World.IsValidPosition(pos) {
+				continue
+			}
+
+			if a.owner.World.At(pos).Tile == core.Wall || !keep(pos) {
+				continue
+			}
+			valid = append(valid, pos)
+		}
+	}
+	return valid
+}
+
+func (a *SaveSpellAction) creaturesInRange(from grid.Position) []grid.Position {
+	return a.squaresInRange(from, a.castRange, func(pos grid.Position) bool {
+		other := a.owner.World.ActorAt(pos)
+		if other == nil || other.IsDead() || other == a.owner {
+			return false
+		}
+		return a.owner.CanAttack(other) && a.owner.World.HasLineOfSight(from, pos)
+	})
+}
+
+func (a *SaveSpellAction) AffectedPositions(tar []grid.Position) []grid.Position {
+	if a.area.Kind == "" {
+		return []grid.Position{tar[0]}
+	}
+
+	return a.owner.World.AreaOfEffect(a.area, a.owner.Position, tar[0])
+}
+
+func (a *SaveSpellAction) Damage() *expression.Expression {
+	if a.baseDamage == nil {
+		return &expression.Expression{}
+	}
+	return a.damageSource(a.owner, a.baseDamage, a.level).Damage()
+}
+
+func (a *SaveSpellAction) AverageDamage() int {
+	return a.Damage().Expected()
+}
+
+// castDamage is the damage source of a save spell cast with slot, the action's tags with the upcast damage of slot
+type castDamage struct {
+	*SaveSpellAction
+	slot int
+}
+
+func (c castDamage) Damage() *expression.Expression {
+	return c.damageSource(c.owner, c.baseDamage, c.slot).Damage()
+}
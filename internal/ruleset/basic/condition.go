@@ -0,0 +1,69 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+	"anvil/internal/tag"
+)
+
+func newConditionEffect(archetype string, name string) *core.Effect {
+	return &core.Effect{Archetype: archetype, Name: name}
+}
+
+func withinFiveFeet(a *core.Actor, b *core.Actor) bool {
+	return a.Position.Distance(b.Position) <= 1
+}
+
+// attacksAgainstHaveAdvantage grants advantage to anyone attacking a creature with the condition
+func attacksAgainstHaveAdvantage(fx *core.Effect, condition tag.Tag) {
+	core.On(fx, func(s *core.PreAttackRoll) {
+		if s.Target.HasCondition(condition, nil) {
+			s.Expression.GiveAdvantage(fx.Name)
+		}
+	})
+}
+
+// attacksHaveDisadvantage imposes disadvantage on the attack rolls of a creature with the condition
+func attacksHaveDisadvantage(fx *core.Effect, condition tag.Tag) {
+	core.On(fx, func(s *core.PreAttackRoll) {
+		if s.Source.HasCondition(condition, nil) {
+			s.Expression.GiveDisadvantage(fx.Name)
+		}
+	})
+}
+
+// criticalWithinFiveFeet turns hits from an adjacent attacker into critical hits
+func criticalWithinFiveFeet(fx *core.Effect, condition tag.Tag) {
+	core.On(fx, func(s *core.PreAttackRoll) {
+		if s.Target.HasCondition(condition, nil) && withinFiveFeet(s.Source, s.Target) {
+			s.CriticalOnHit = true
+		}
+	})
+}
+
+// failsStrengthAndDexteritySaves makes strength and dexterity saving throws fail automatically
+func failsStrengthAndDexteritySaves(fx *core.Effect, condition tag.Tag) {
+	core.On(fx, func(s *core.PreSavingThrow) {
+		if !s.Source.HasCondition(condition, nil) {
+			return
+		}
+		if s.Attribute.MatchExact(tags.AttributeStrength) || s.Attribute.MatchExact(tags.AttributeDexterity) {
+			s.AutoFail = true
+		}
+	})
+}
+
+// immobilized sets the speed of a creature with the condition to 0
+func immobilized(fx *core.Effect, condition tag.Tag) {
+	core.On(fx, func(s *core.TurnStarted) {
+		if s.Source.HasCondition(condition, nil) {
+			s.Source.Resources.ClearSpeed()
+		}
+	})
+
+	core.On(fx, func(s *core.PreMoveStep) {
+		if s.Source.HasCondition(condition, nil) {
+			s.CanMove = false
+		}
+	})
+}
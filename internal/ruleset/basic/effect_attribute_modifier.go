@@ -17,7 +17,8 @@ func NewAttributeModifierEffect() *core.Effect {
 		dex := src.Attribute(tags.AttributeDexterity)
 		strMod := stats.AttributeModifier(str.Value)
 		dexMod := stats.AttributeModifier(dex.Value)
-		if tc.MatchTag(tags.Finesse) || tc.MatchTag(tags.Ranged) {
+		// thrown weapons keep the ability they use in melee
+		if tc.MatchTag(tags.Finesse) || (tc.MatchTag(tags.Ranged) && !tc.MatchTag(tags.Thrown)) {
 			e.AddConstant(dexMod, "Attribute Modifier (Dexterity)", dex.Components...)
 			return
 		}
@@ -33,7 +34,7 @@ func NewAttributeModifierEffect() *core.Effect {
 
 	fx := &core.Effect{Name: "Attribute Modifier", Priority: core.PriorityBase}
 
-	fx.On(func(s *core.PreAttackRoll) {
+	core.On(fx, func(s *core.PreAttackRoll) {
 		if s.Tags.HasTag(tags.Ranged) || s.Tags.HasTag(tags.Melee) {
 			applyAttackModifier(s.Source, s.Expression, s.Tags)
 		}
@@ -43,17 +44,18 @@ func NewAttributeModifierEffect() *core.Effect {
 		}
 	})
 
-	fx.On(func(s *core.PreDamageRoll) {
+	core.On(fx, func(s *core.PreDamageRoll) {
 		if s.Tags.HasTag(tags.Ranged) || s.Tags.HasTag(tags.Melee) {
 			applyAttackModifier(s.Source, s.Expression, s.Tags)
 		}
 
-		if s.Tags.HasTag(tags.Spell) {
+		// spells forcing a saving throw do not add the casting modifier to their damage
+		if s.Tags.HasTag(tags.Spell) && !s.Tags.HasTag(tags.SpellSave) {
 			applySpellModifier(s.Source, s.Expression)
 		}
 	})
 
-	fx.On(func(s *core.PreSavingThrow) {
+	core.On(fx, func(s *core.PreSavingThrow) {
 		if s.Attribute.MatchExact(tags.ActorHitPoints) {
 			return
 		}
@@ -63,5 +65,11 @@ func NewAttributeModifierEffect() *core.Effect {
 		s.Expression.AddConstant(mod, "Attribute Modifier ("+tags.ToReadable(s.Attribute)+")", attr.Components...)
 	})
 
+	core.On(fx, func(s *core.PreAbilityCheck) {
+		attr := s.Source.Attribute(s.Attribute)
+		mod := stats.AttributeModifier(attr.Value)
+		s.Expression.AddConstant(mod, "Attribute Modifier ("+tags.ToReadable(s.Attribute)+")", attr.Components...)
+	})
+
 	return fx
 }
@@ -0,0 +1,15 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewBlindedEffect gives blinded creatures disadvantage on attacks and advantage to attacks against them
+func NewBlindedEffect() *core.Effect {
+	fx := newConditionEffect("condition-blinded", "Blinded")
+	attacksHaveDisadvantage(fx, tags.Blinded)
+	attacksAgainstHaveAdvantage(fx, tags.Blinded)
+
+	return fx
+}
@@ -0,0 +1,20 @@
+package basic
+
+import (
+	"slices"
+
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewCharmedEffect stops charmed creatures from attacking whoever charmed them
+func NewCharmedEffect() *core.Effect {
+	fx := newConditionEffect("condition-charmed", "Charmed")
+	core.On(fx, func(s *core.AttackAllowed) {
+		if slices.Contains(s.Source.ConditionSources(tags.Charmed), s.Target) {
+			s.Allowed = false
+		}
+	})
+
+	return fx
+}
@@ -0,0 +1,8 @@
+package basic
+
+import "anvil/internal/core"
+
+// NewDeafenedEffect tracks deafened creatures, nothing in the engine depends on hearing yet
+func NewDeafenedEffect() *core.Effect {
+	return newConditionEffect("condition-deafened", "Deafened")
+}
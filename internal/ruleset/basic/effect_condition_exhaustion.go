@@ -0,0 +1,54 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewExhaustionEffect subtracts twice the exhaustion level from every d20 test and the level from speed,
+// a long rest removes one level and reaching level 6 kills
+func NewExhaustionEffect() *core.Effect {
+	fx := newConditionEffect("condition-exhaustion", "Exhaustion")
+	penalty := func(a *core.Actor) int {
+		return -2 * a.ConditionLevel(tags.Exhaustion)
+	}
+
+	core.On(fx, func(s *core.PreAttackRoll) {
+		if p := penalty(s.Source); p != 0 {
+			s.Expression.AddConstant(p, fx.Name)
+		}
+	})
+
+	core.On(fx, func(s *core.PreSavingThrow) {
+		if p := penalty(s.Source); p != 0 {
+			s.Expression.AddConstant(p, fx.Name)
+		}
+	})
+
+	core.On(fx, func(s *core.PreAbilityCheck) {
+		if p := penalty(s.Source); p != 0 {
+			s.Expression.AddConstant(p, fx.Name)
+		}
+	})
+
+	core.On(fx, func(s *core.TurnStarted) {
+		if level := s.Source.ConditionLevel(tags.Exhaustion); level > 0 {
+			s.Source.Resources.Consume(tags.ResourceWalkSpeed, level)
+		}
+	})
+
+	core.On(fx, func(s *core.ConditionChanged) {
+		if !s.Condition.MatchExact(tags.Exhaustion) || s.Source.IsDead() {
+			return
+		}
+		if s.Source.ConditionLevel(tags.Exhaustion) >= core.ExhaustionMaxLevel {
+			s.Source.Die()
+		}
+	})
+
+	core.On(fx, func(s *core.LongRestFinished) {
+		s.Source.ChangeConditionLevel(tags.Exhaustion, -1, fx)
+	})
+
+	return fx
+}
@@ -0,0 +1,43 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewFrightenedEffect gives frightened creatures disadvantage on attacks and ability checks while the source of their
+// fear is in sight, they can't willingly move closer to it
+func NewFrightenedEffect() *core.Effect {
+	fx := newConditionEffect("condition-frightened", "Frightened")
+	seesFear := func(a *core.Actor) bool {
+		for _, fear := range a.ConditionSources(tags.Frightened) {
+			if a.World.HasLineOfSight(a.Position, fear.Position) {
+				return true
+			}
+		}
+		return false
+	}
+
+	core.On(fx, func(s *core.PreAttackRoll) {
+		if seesFear(s.Source) {
+			s.Expression.GiveDisadvantage(fx.Name)
+		}
+	})
+
+	core.On(fx, func(s *core.PreAbilityCheck) {
+		if seesFear(s.Source) {
+			s.Expression.GiveDisadvantage(fx.Name)
+		}
+	})
+
+	core.On(fx, func(s *core.PreMoveStep) {
+		for _, fear := range s.Source.ConditionSources(tags.Frightened) {
+			if s.To.Distance(fear.Position) < s.From.Distance(fear.Position) {
+				s.CanMove = false
+				return
+			}
+		}
+	})
+
+	return fx
+}
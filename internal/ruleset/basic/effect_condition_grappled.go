@@ -0,0 +1,22 @@
+package basic
+
+import (
+	"slices"
+
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewGrappledEffect stops grappled creatures from moving and gives them disadvantage attacking anyone but the grappler
+func NewGrappledEffect() *core.Effect {
+	fx := newConditionEffect("condition-grappled", "Grappled")
+	immobilized(fx, tags.Grappled)
+	core.On(fx, func(s *core.PreAttackRoll) {
+		grapplers := s.Source.ConditionSources(tags.Grappled)
+		if s.Source.HasCondition(tags.Grappled, nil) && !slices.Contains(grapplers, s.Target) {
+			s.Expression.GiveDisadvantage(fx.Name)
+		}
+	})
+
+	return fx
+}
@@ -0,0 +1,18 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewIncapacitatedEffect makes incapacitated creatures roll initiative with disadvantage, CanAct covers the rest
+func NewIncapacitatedEffect() *core.Effect {
+	fx := newConditionEffect("condition-incapacitated", "Incapacitated")
+	core.On(fx, func(s *core.PreInitiative) {
+		if s.Source.MatchCondition(tags.Incapacitated) {
+			s.Expression.GiveDisadvantage(fx.Name)
+		}
+	})
+
+	return fx
+}
@@ -0,0 +1,21 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewInvisibleEffect gives invisible creatures advantage on attacks and disadvantage to attacks against them
+func NewInvisibleEffect() *core.Effect {
+	fx := newConditionEffect("condition-invisible", "Invisible")
+	core.On(fx, func(s *core.PreAttackRoll) {
+		if s.Source.HasCondition(tags.Invisible, nil) {
+			s.Expression.GiveAdvantage(fx.Name)
+		}
+		if s.Target.HasCondition(tags.Invisible, nil) {
+			s.Expression.GiveDisadvantage(fx.Name)
+		}
+	})
+
+	return fx
+}
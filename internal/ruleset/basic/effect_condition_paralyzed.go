@@ -0,0 +1,18 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewParalyzedEffect stops paralyzed creatures from moving and fails their strength and dexterity saves,
+// attacks against them have advantage and hits from within 5 feet are critical
+func NewParalyzedEffect() *core.Effect {
+	fx := newConditionEffect("condition-paralyzed", "Paralyzed")
+	immobilized(fx, tags.Paralyzed)
+	failsStrengthAndDexteritySaves(fx, tags.Paralyzed)
+	attacksAgainstHaveAdvantage(fx, tags.Paralyzed)
+	criticalWithinFiveFeet(fx, tags.Paralyzed)
+
+	return fx
+}
@@ -0,0 +1,23 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewPetrifiedEffect stops petrified creatures from moving and fails their strength and dexterity saves,
+// attacks against them have advantage and they resist all damage and are immune to poison
+func NewPetrifiedEffect() *core.Effect {
+	fx := newConditionEffect("condition-petrified", "Petrified")
+	immobilized(fx, tags.Petrified)
+	failsStrengthAndDexteritySaves(fx, tags.Petrified)
+	attacksAgainstHaveAdvantage(fx, tags.Petrified)
+	core.On(fx, func(s *core.DamageTraitsCalculation) {
+		if s.Source.HasCondition(tags.Petrified, nil) {
+			s.Traits.AddResistance(tags.DamageKind, fx.Name)
+			s.Traits.AddImmunity(tags.Poison, fx.Name)
+		}
+	})
+
+	return fx
+}
@@ -0,0 +1,19 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewPoisonedEffect gives poisoned creatures disadvantage on attack rolls and ability checks
+func NewPoisonedEffect() *core.Effect {
+	fx := newConditionEffect("condition-poisoned", "Poisoned")
+	attacksHaveDisadvantage(fx, tags.Poisoned)
+	core.On(fx, func(s *core.PreAbilityCheck) {
+		if s.Source.HasCondition(tags.Poisoned, nil) {
+			s.Expression.GiveDisadvantage(fx.Name)
+		}
+	})
+
+	return fx
+}
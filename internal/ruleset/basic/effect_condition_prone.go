@@ -0,0 +1,25 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewProneEffect gives prone creatures disadvantage on attacks, attacks against them have advantage from within
+// 5 feet and disadvantage from further away
+func NewProneEffect() *core.Effect {
+	fx := newConditionEffect("condition-prone", "Prone")
+	attacksHaveDisadvantage(fx, tags.Prone)
+	core.On(fx, func(s *core.PreAttackRoll) {
+		if !s.Target.HasCondition(tags.Prone, nil) {
+			return
+		}
+		if withinFiveFeet(s.Source, s.Target) {
+			s.Expression.GiveAdvantage(fx.Name)
+		} else {
+			s.Expression.GiveDisadvantage(fx.Name)
+		}
+	})
+
+	return fx
+}
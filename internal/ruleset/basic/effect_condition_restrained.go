@@ -0,0 +1,22 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewRestrainedEffect stops restrained creatures from moving, gives them disadvantage on attacks and dexterity saves
+// and advantage to attacks against them
+func NewRestrainedEffect() *core.Effect {
+	fx := newConditionEffect("condition-restrained", "Restrained")
+	immobilized(fx, tags.Restrained)
+	attacksHaveDisadvantage(fx, tags.Restrained)
+	attacksAgainstHaveAdvantage(fx, tags.Restrained)
+	core.On(fx, func(s *core.PreSavingThrow) {
+		if s.Source.HasCondition(tags.Restrained, nil) && s.Attribute.MatchExact(tags.AttributeDexterity) {
+			s.Expression.GiveDisadvantage(fx.Name)
+		}
+	})
+
+	return fx
+}
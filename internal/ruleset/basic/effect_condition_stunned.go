@@ -0,0 +1,15 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewStunnedEffect fails the strength and dexterity saves of stunned creatures, attacks against them have advantage
+func NewStunnedEffect() *core.Effect {
+	fx := newConditionEffect("condition-stunned", "Stunned")
+	failsStrengthAndDexteritySaves(fx, tags.Stunned)
+	attacksAgainstHaveAdvantage(fx, tags.Stunned)
+
+	return fx
+}
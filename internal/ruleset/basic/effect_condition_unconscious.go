@@ -0,0 +1,18 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewUnconsciousEffect stops unconscious creatures from moving and fails their strength and dexterity saves,
+// attacks against them have advantage and hits from within 5 feet are critical
+func NewUnconsciousEffect() *core.Effect {
+	fx := newConditionEffect("condition-unconscious", "Unconscious")
+	immobilized(fx, tags.Unconscious)
+	failsStrengthAndDexteritySaves(fx, tags.Unconscious)
+	attacksAgainstHaveAdvantage(fx, tags.Unconscious)
+	criticalWithinFiveFeet(fx, tags.Unconscious)
+
+	return fx
+}
@@ -0,0 +1,22 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/tag"
+
+	"github.com/google/uuid"
+)
+
+func NewDamageResistanceEffect(kind tag.Tag) *core.Effect {
+	fx := &core.Effect{
+		Archetype: "damage-resistance",
+		ID:        uuid.New().String(),
+		Name:      "Damage Resistance",
+	}
+
+	core.On(fx, func(s *core.DamageTraitsCalculation) {
+		s.Traits.AddResistance(kind, fx.Name)
+	})
+
+	return fx
+}
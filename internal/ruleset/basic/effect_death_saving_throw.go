@@ -34,11 +34,11 @@ func NewDeathSavingThrowEffect() *core.Effect {
 		return false
 	}
 
-	fx.On(func(s *core.AttributeChanged) {
+	core.On(fx, func(s *core.AttributeChanged) {
 		if !s.Attribute.MatchExact(tags.ActorHitPoints) {
 			return
 		}
-		if s.Value != 0 {
+		if s.OldValue != 0 || s.Value == 0 {
 			return
 		}
 		reset()
@@ -46,14 +46,14 @@ func NewDeathSavingThrowEffect() *core.Effect {
 		s.Source.RemoveCondition(tags.Unconscious, nil)
 	})
 
-	fx.On(func(s *core.ConditionChanged) {
+	core.On(fx, func(s *core.ConditionChanged) {
 		if !s.Condition.Match(tags.Unconscious) {
 			return
 		}
 		reset()
 	})
 
-	fx.On(func(s *core.PostTakeDamage) {
+	core.On(fx, func(s *core.PostTakeDamage) {
 		if s.Source.HitPoints > 0 {
 			return
 		}
@@ -76,7 +76,7 @@ func NewDeathSavingThrowEffect() *core.Effect {
 		}
 	})
 
-	fx.On(func(s *core.TurnStarted) {
+	core.On(fx, func(s *core.TurnStarted) {
 		if !s.Source.MatchCondition(tags.Unconscious) {
 			return
 		}
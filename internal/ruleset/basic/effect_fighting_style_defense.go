@@ -14,7 +14,7 @@ func NewFightingStyleDefense() *core.Effect {
 		ID:        uuid.New().String(),
 		Name:      "Fighting Style: Defense",
 	}
-	fx.On(func(s *core.AttributeCalculation) {
+	core.On(fx, func(s *core.AttributeCalculation) {
 		if !s.Attribute.MatchExact(tags.ActorArmorClass) {
 			return
 		}
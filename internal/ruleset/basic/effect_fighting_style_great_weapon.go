@@ -0,0 +1,30 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+
+	"github.com/google/uuid"
+)
+
+func NewFightingStyleGreatWeapon() *core.Effect {
+	fx := &core.Effect{
+		Archetype: "fighting-style-great-weapon",
+		ID:        uuid.New().String(),
+		Name:      "Fighting Style: Great Weapon Fighting",
+	}
+
+	core.On(fx, func(s *core.PreDamageRoll) {
+		if !s.Tags.MatchTag(tags.Attack) || s.Tags.HasTag(tags.Ranged) || s.Tags.MatchTag(tags.Spell) {
+			return
+		}
+
+		if !s.Tags.MatchTag(tags.TwoHanded) && !s.Tags.MatchTag(tags.Versatile) {
+			return
+		}
+
+		s.Expression.MinimumFace(3, fx.Name)
+	})
+
+	return fx
+}
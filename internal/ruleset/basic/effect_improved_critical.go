@@ -0,0 +1,26 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+
+	"github.com/google/uuid"
+)
+
+func NewImprovedCriticalEffect(threshold int) *core.Effect {
+	fx := &core.Effect{
+		Archetype: "improved-critical",
+		ID:        uuid.New().String(),
+		Name:      "Improved Critical",
+	}
+
+	core.On(fx, func(s *core.PreAttackRoll) {
+		if !s.Tags.MatchTag(tags.Attack) || s.Tags.MatchTag(tags.Spell) {
+			return
+		}
+
+		s.Expression.ExpandCriticalRange(threshold, fx.Name)
+	})
+
+	return fx
+}
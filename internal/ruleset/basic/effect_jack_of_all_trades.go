@@ -0,0 +1,28 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/tag"
+
+	"github.com/google/uuid"
+)
+
+// NewJackOfAllTradesEffect adds half the proficiency bonus to ability checks without proficiency
+func NewJackOfAllTradesEffect() *core.Effect {
+	fx := &core.Effect{
+		Archetype: "jack-of-all-trades",
+		ID:        uuid.New().String(),
+		Name:      "Jack of All Trades",
+	}
+
+	core.On(fx, func(s *core.PreAbilityCheck) {
+		if !s.Skill.IsEmpty() && s.Source.Proficiency(tag.ContainerFromTag(s.Skill)) != 0 {
+			return
+		}
+		if half := s.Source.Proficiencies.Bonus / 2; half != 0 {
+			s.Expression.AddConstant(half, fx.Name)
+		}
+	})
+
+	return fx
+}
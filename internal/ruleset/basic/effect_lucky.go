@@ -0,0 +1,29 @@
+package basic
+
+import (
+	"anvil/internal/core"
+
+	"github.com/google/uuid"
+)
+
+func NewLuckyEffect() *core.Effect {
+	fx := &core.Effect{
+		Archetype: "lucky",
+		ID:        uuid.New().String(),
+		Name:      "Lucky",
+	}
+
+	core.On(fx, func(s *core.PreAttackRoll) {
+		s.Expression.RerollBelow(2, fx.Name)
+	})
+
+	core.On(fx, func(s *core.PreSavingThrow) {
+		s.Expression.RerollBelow(2, fx.Name)
+	})
+
+	core.On(fx, func(s *core.PreAbilityCheck) {
+		s.Expression.RerollBelow(2, fx.Name)
+	})
+
+	return fx
+}
@@ -18,14 +18,14 @@ var saveMap = map[tag.Tag]tag.Tag{
 func NewProficiencyModifierEffect() *core.Effect {
 	fx := &core.Effect{Name: "Proficiency Modifier", Priority: core.PriorityBase}
 
-	fx.On(func(s *core.PreAttackRoll) {
+	core.On(fx, func(s *core.PreAttackRoll) {
 		proficiency := s.Source.Proficiency(s.Tags)
 		if proficiency != 0 {
 			s.Expression.AddConstant(proficiency, "Proficiency Modifier")
 		}
 	})
 
-	fx.On(func(s *core.PreSavingThrow) {
+	core.On(fx, func(s *core.PreSavingThrow) {
 		t, ok := saveMap[s.Attribute]
 		if !ok {
 			return
@@ -36,5 +36,15 @@ func NewProficiencyModifierEffect() *core.Effect {
 		}
 	})
 
+	core.On(fx, func(s *core.PreAbilityCheck) {
+		if s.Skill.IsEmpty() {
+			return
+		}
+		proficiency := s.Source.Proficiency(tag.ContainerFromTag(s.Skill))
+		if proficiency != 0 {
+			s.Expression.AddConstant(proficiency, "Proficiency Modifier")
+		}
+	})
+
 	return fx
 }
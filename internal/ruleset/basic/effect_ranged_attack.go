@@ -0,0 +1,51 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+	"anvil/internal/grid"
+)
+
+// NewRangedAttackEffect imposes disadvantage on ranged attacks beyond normal range or made next to a hostile creature
+// that is not incapacitated
+func NewRangedAttackEffect() *core.Effect {
+	fx := &core.Effect{Name: "Ranged Attack", Priority: core.PriorityBase}
+
+	core.On(fx, func(s *core.PreAttackRoll) {
+		if !s.Tags.MatchTag(tags.Ranged) {
+			return
+		}
+
+		if s.Tags.HasTag(tags.LongRange) {
+			s.Expression.GiveDisadvantage("Long Range")
+		}
+
+		if hostileAdjacent(s.Source) {
+			s.Expression.GiveDisadvantage("Hostile Creature Adjacent")
+		}
+	})
+
+	return fx
+}
+
+func hostileAdjacent(a *core.Actor) bool {
+	if a.World == nil {
+		return false
+	}
+
+	for y := -1; y <= 1; y++ {
+		for x := -1; x <= 1; x++ {
+			pos := a.Position.Add(grid.Position{X: x, Y: y})
+			if pos == a.Position || !a.World.IsValidPosition(pos) {
+				continue
+			}
+
+			other := a.World.ActorAt(pos)
+			if other != nil && other.CanAct() && a.IsHostileTo(other) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
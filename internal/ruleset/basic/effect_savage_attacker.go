@@ -0,0 +1,38 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+
+	"github.com/google/uuid"
+)
+
+func NewSavageAttackerEffect() *core.Effect {
+	fx := &core.Effect{
+		Archetype: "savage-attacker",
+		ID:        uuid.New().String(),
+		Name:      "Savage Attacker",
+		// Before other effects add their dice, only the weapon's dice are rolled twice
+		Priority: core.PriorityBase,
+	}
+
+	// Once per turn, outside of an encounter every hit is its own turn
+	used := false
+	round, turn := 0, 0
+	core.On(fx, func(s *core.PreDamageRoll) {
+		if !s.Tags.MatchTag(tags.Attack) || s.Tags.MatchTag(tags.Spell) {
+			return
+		}
+
+		if e := s.Source.Encounter; e != nil {
+			if used && e.Round == round && e.Turn == turn {
+				return
+			}
+			used, round, turn = true, e.Round, e.Turn
+		}
+
+		s.Expression.BestOf(2, fx.Name)
+	})
+
+	return fx
+}
@@ -23,7 +23,7 @@ func newChainMailEffect() *core.Effect {
 		Priority:  core.PriorityBaseOverride,
 	}
 
-	fx.On(func(s *core.AttributeCalculation) {
+	core.On(fx, func(s *core.AttributeCalculation) {
 		if !s.Attribute.MatchExact(tags.ActorArmorClass) {
 			return
 		}
@@ -61,3 +61,7 @@ func (c *ChainMail) Tags() *tag.Container {
 func (c *ChainMail) OnEquip(a *core.Actor) {
 	a.AddEffect(newChainMailEffect())
 }
+
+func (c *ChainMail) OnUnequip(a *core.Actor) {
+	a.Effects.RemoveAll("chain-mail")
+}
@@ -2,8 +2,8 @@ package basic
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
+	"slices"
+	"sync"
 
 	"anvil/internal/core"
 	"anvil/internal/core/tags"
@@ -14,6 +14,13 @@ import (
 	"github.com/google/uuid"
 )
 
+// equippedActions holds the IDs of the actions each equipped weapon added, by weapon ID, so unequipping one of two
+// identical weapons leaves the other's actions
+var equippedActions = struct {
+	mu  sync.Mutex
+	ids map[string][]string
+}{ids: make(map[string][]string)}
+
 type Weapon struct {
 	archetype string
 	id        string
@@ -21,6 +28,9 @@ type Weapon struct {
 	damage    expression.Expression
 	tags      tag.Container
 	reach     int
+	// normalRange and longRange are in grid squares, 0 for weapons that cannot make ranged attacks
+	normalRange int
+	longRange   int
 }
 
 func NewWeapon(archetype, id, name string, damage expression.Expression, weaponTags tag.Container, reach int) *Weapon {
@@ -35,9 +45,10 @@ func NewWeapon(archetype, id, name string, damage expression.Expression, weaponT
 }
 
 func NewWeaponFromDefinition(def loader.WeaponDefinition) *Weapon {
-	damageExpr := expression.Expression{Rng: expression.NewRngRoller()}
+	damageExpr := expression.Expression{}
 	for _, dmg := range def.Damage {
-		if err := parseDamageFormula(dmg.Formula, def.Name, dmg.Kind, &damageExpr); err != nil {
+		damageTags := tag.ContainerFromTag(tags.DamageKindFromString(dmg.Kind))
+		if err := damageExpr.AddDamageFormula(dmg.Formula, damageTags, def.Name); err != nil {
 			panic(fmt.Sprintf("invalid damage formula '%s' for weapon '%s': %v", dmg.Formula, def.Archetype, err))
 		}
 	}
@@ -48,38 +59,15 @@ func NewWeaponFromDefinition(def loader.WeaponDefinition) *Weapon {
 	}
 
 	return &Weapon{
-		archetype: def.Archetype,
-		id:        uuid.New().String(),
-		name:      def.Name,
-		damage:    damageExpr,
-		tags:      tag.ContainerFromTag(weaponTags...),
-		reach:     def.Reach,
-	}
-}
-
-func parseDamageFormula(formula, weaponName, kind string, expr *expression.Expression) error {
-	diceRe := regexp.MustCompile(`^(\d+)d(\d+)$`)
-	if matches := diceRe.FindStringSubmatch(formula); len(matches) == 3 {
-		times, err := strconv.Atoi(matches[1])
-		if err != nil {
-			return fmt.Errorf("invalid number of dice: %s", matches[1])
-		}
-
-		sides, err := strconv.Atoi(matches[2])
-		if err != nil {
-			return fmt.Errorf("invalid number of sides: %s", matches[2])
-		}
-
-		expr.AddDamageDice(times, sides, tag.ContainerFromTag(tag.FromString(kind)), weaponName)
-		return nil
-	}
-
-	if constant, err := strconv.Atoi(formula); err == nil {
-		expr.AddDamageConstant(constant, tag.ContainerFromTag(tag.FromString(kind)), weaponName)
-		return nil
+		archetype:   def.Archetype,
+		id:          uuid.New().String(),
+		name:        def.Name,
+		damage:      damageExpr,
+		tags:        tag.ContainerFromTag(weaponTags...),
+		reach:       def.Reach,
+		normalRange: def.Range,
+		longRange:   def.LongRange,
 	}
-
-	return fmt.Errorf("invalid damage formula format: %s (expected format like '1d4', '2d6', or '5')", formula)
 }
 
 func (w Weapon) Archetype() string {
@@ -100,8 +88,54 @@ func (w Weapon) Tags() *tag.Container {
 }
 
 func (w Weapon) OnEquip(a *core.Actor) {
-	cost := map[tag.Tag]int{tags.ResourceAction: 1}
-	a.AddAction(NewMeleeAction(a, fmt.Sprintf("Attack with %s", w.name), &w, w.reach, w.tags, cost))
+	actions := make([]core.Action, 0, 2)
+	if w.reach > 0 {
+		cost := map[tag.Tag]int{tags.ResourceAction: 1}
+		actions = append(actions, NewMeleeAction(a, w.meleeActionName(), &w, w.reach, w.tags.Clone(), cost))
+	}
+
+	if w.normalRange > 0 {
+		cost := map[tag.Tag]int{tags.ResourceAction: 1}
+		if w.tags.MatchTag(tags.Ammunition) {
+			cost[tags.ResourceAmmunition] = 1
+		}
+		action := NewRangedAction(a, w.rangedActionName(), &w, w.normalRange, w.longRange, w.tags.Clone(), cost)
+		if w.tags.MatchTag(tags.Thrown) {
+			action.thrown = &w
+		}
+		actions = append(actions, action)
+	}
+
+	equippedActions.mu.Lock()
+	defer equippedActions.mu.Unlock()
+	for _, action := range actions {
+		a.AddAction(action)
+		equippedActions.ids[w.id] = append(equippedActions.ids[w.id], action.ID())
+	}
+}
+
+func (w Weapon) OnUnequip(a *core.Actor) {
+	equippedActions.mu.Lock()
+	ids := equippedActions.ids[w.id]
+	delete(equippedActions.ids, w.id)
+	equippedActions.mu.Unlock()
+
+	for _, action := range slices.Clone(a.Actions) {
+		if slices.Contains(ids, action.ID()) {
+			a.RemoveAction(action)
+		}
+	}
+}
+
+func (w Weapon) meleeActionName() string {
+	return fmt.Sprintf("Attack with %s", w.name)
+}
+
+func (w Weapon) rangedActionName() string {
+	if w.tags.MatchTag(tags.Thrown) {
+		return fmt.Sprintf("Throw %s", w.name)
+	}
+	return fmt.Sprintf("Shoot %s", w.name)
 }
 
 func (w Weapon) Damage() *expression.Expression {
@@ -0,0 +1,27 @@
+package basic
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+)
+
+// NewOpportunityAttackReaction strikes a hostile actor leaving the reach of the owner's best weapon
+func NewOpportunityAttackReaction(weapon *MeleeAction) *core.Reaction {
+	owner := weapon.owner
+	r := core.NewReaction(owner, "Opportunity Attack", "Take attack of opportunity?")
+	core.OnTrigger(r, func(s *core.PreMoveStep) bool {
+		if s.Action != nil && s.Action.Tags().MatchTag(tags.Teleport) {
+			return false
+		}
+
+		if !s.CanMove || !s.Source.IsHostileTo(owner) || !owner.CanAttack(s.Source) || owner.BestWeaponAttack() != weapon {
+			return false
+		}
+
+		return s.From.Distance(owner.Position) <= weapon.reach && s.To.Distance(owner.Position) > weapon.reach
+	}, func(s *core.PreMoveStep) {
+		owner.Dispatcher.Emit(core.TargetEvent{Target: []*core.Actor{s.Source}})
+		weapon.strike(s.Source)
+	})
+	return r
+}
@@ -0,0 +1,121 @@
+package basic
+
+import (
+	"fmt"
+
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+	"anvil/internal/expression"
+	"anvil/internal/loader"
+	"anvil/internal/tag"
+)
+
+// spell is what spell actions share: the spell level, the slot it is cast with and how its damage scales
+type spell struct {
+	name   string
+	level  int
+	ritual bool
+	// upcast is the formula added per slot level above level, or per cantrip tier above the first
+	upcast string
+}
+
+func newSpell(def loader.SpellActionDefinition) spell {
+	if def.Upcast != "" {
+		if _, err := expression.FromDamageFormula(def.Upcast, tag.Container{}, def.Name); err != nil {
+			panic(fmt.Sprintf("invalid upcast formula '%s' for spell '%s': %v", def.Upcast, def.Name, err))
+		}
+	}
+
+	return spell{name: def.Name, level: def.Level, ritual: def.Ritual, upcast: def.Upcast}
+}
+
+// spellTags are the tags every spell action carries, its ritual and component tags included
+func spellTags(def loader.SpellActionDefinition) tag.Container {
+	actionTags := tag.ContainerFromTag(tags.Spell)
+	for _, tagStr := range def.Tags {
+		actionTags.Add(tag.ContainerFromTag(tag.FromString(tagStr)))
+	}
+
+	for _, component := range def.Components {
+		actionTags.Add(tag.ContainerFromTag(tags.ComponentFromString(component)))
+	}
+
+	if def.Ritual {
+		actionTags.Add(tag.ContainerFromTag(tags.Ritual))
+	}
+
+	return actionTags
+}
+
+// cantripTier is 1 to 4, cantrips get stronger at character levels 5, 11 and 17
+func cantripTier(level int) int {
+	switch {
+	case level >= 17:
+		return 4
+	case level >= 11:
+		return 3
+	case level >= 5:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func (s spell) SpellLevel() int {
+	return s.level
+}
+
+// asRitual is true when the spell is cast as a ritual, which takes ten extra minutes and is only possible outside
+// of an encounter
+func (s spell) asRitual(owner *core.Actor) bool {
+	return s.ritual && s.level > 0 && owner.Encounter == nil
+}
+
+func (s spell) hasSlot(owner *core.Actor) bool {
+	return s.level == 0 || s.asRitual(owner) || len(owner.SpellSlotLevels(s.level)) > 0
+}
+
+// chooseSlot returns the slot level to cast with, 0 for cantrips and rituals
+func (s spell) chooseSlot(owner *core.Actor) int {
+	if s.level == 0 || s.asRitual(owner) {
+		return 0
+	}
+
+	slot, ok := owner.ChooseSpellSlot(s.name, s.level)
+	if !ok {
+		panic(fmt.Sprintf("no spell slot left to cast '%s'", s.name))
+	}
+
+	return slot
+}
+
+// scaling is how many times the upcast formula is added when cast with slot
+func (s spell) scaling(owner *core.Actor, slot int) int {
+	if s.upcast == "" {
+		return 0
+	}
+
+	if s.level == 0 {
+		return cantripTier(owner.Level) - 1
+	}
+
+	return max(slot-s.level, 0)
+}
+
+// damageSource adds the upcast dice to source, which is returned untouched when the spell does not scale
+func (s spell) damageSource(owner *core.Actor, source core.DamageSource, slot int) core.DamageSource {
+	times := s.scaling(owner, slot)
+	if source == nil || times == 0 {
+		return source
+	}
+
+	damage := source.Damage()
+	damageTags := *source.Tags()
+	for range times {
+		if err := damage.AddDamageFormula(s.upcast, damageTags, "Upcast"); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	return core.NewDamageSource(*damage, damageTags)
+}
@@ -0,0 +1,69 @@
+package ruleset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+	"anvil/internal/tag"
+)
+
+func TestChecks_SkillModifiers(t *testing.T) {
+	bard := func(def *loader.ActorDefinition) {
+		def.Attributes = loader.AttributesDefinition{Strength: 10, Dexterity: 14, Wisdom: 12}
+		def.Proficiencies = loader.ProficienciesDefinition{
+			Bonus:     3,
+			Skills:    []string{"Proficiency.Athletics"},
+			Expertise: []string{"Proficiency.Stealth"},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		attribute tag.Tag
+		skill     tag.Tag
+		jack      bool
+		want      int
+	}{
+		{name: "proficient", attribute: tags.AttributeStrength, skill: tags.ProficiencyAthletics, want: 13},
+		{name: "expertise", attribute: tags.AttributeDexterity, skill: tags.ProficiencyStealth, want: 18},
+		{name: "untrained", attribute: tags.AttributeWisdom, skill: tags.ProficiencyPerception, want: 11},
+		{name: "jack of all trades", attribute: tags.AttributeWisdom, skill: tags.ProficiencyPerception, jack: true, want: 12},
+		{name: "jack of all trades does not stack", attribute: tags.AttributeStrength, skill: tags.ProficiencyAthletics, jack: true, want: 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(10)
+			actor := f.actor("Bard", "players", grid.Position{}, bard)
+			if tt.jack {
+				actor.AddEffect(f.registry.NewEffect("jack-of-all-trades", nil))
+			}
+			result := actor.AbilityCheck(tt.attribute, tt.skill, 10)
+
+			assert.Equal(t, tt.want, result.Value)
+			assert.Equal(t, tt.want, actor.PassiveScore(tt.attribute, tt.skill))
+		})
+	}
+
+	t.Run("initiative rolls as a dexterity check", func(t *testing.T) {
+		// disadvantage keeps the 4, then Dexterity 14 adds 2 and Jack of All Trades half the proficiency bonus
+		f := newFixture(4, 17)
+		actor := f.actor("Bard", "players", grid.Position{}, bard)
+		actor.AddCondition(tags.Poisoned, condition())
+		actor.AddEffect(f.registry.NewEffect("jack-of-all-trades", nil))
+
+		assert.Equal(t, 7, actor.RollInitiative())
+	})
+
+	t.Run("poisoned creatures check with disadvantage", func(t *testing.T) {
+		f := newFixture(10)
+		actor := f.actor("Bard", "players", grid.Position{}, bard)
+		actor.AddCondition(tags.Poisoned, condition())
+
+		assert.Equal(t, 8, actor.PassiveScore(tags.AttributeStrength, tags.ProficiencyAthletics))
+	})
+}
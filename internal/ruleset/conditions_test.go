@@ -0,0 +1,253 @@
+package ruleset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+	"anvil/internal/grid"
+	"anvil/internal/tag"
+)
+
+// attackDie is the d20 kept by attacker's roll against target, showing whether advantage or disadvantage applied
+func attackDie(attacker, target *core.Actor) (int, core.CheckResult) {
+	var result core.CheckResult
+	roll := attackRoll(attacker, func() { result = attacker.AttackRoll(target, tag.ContainerFromTag()) })
+	return roll.Components[0].Value(), result
+}
+
+func condition() *core.Effect {
+	return &core.Effect{Name: "Spell"}
+}
+
+func TestConditions_AttackRolls(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition tag.Tag
+		onTarget  bool
+		distance  int
+		want      int
+	}{
+		{name: "blinded attackers have disadvantage", condition: tags.Blinded, want: 4},
+		{name: "attacks against the blinded have advantage", condition: tags.Blinded, onTarget: true, want: 17},
+		{name: "invisible attackers have advantage", condition: tags.Invisible, want: 17},
+		{name: "attacks against the invisible have disadvantage", condition: tags.Invisible, onTarget: true, want: 4},
+		{name: "poisoned attackers have disadvantage", condition: tags.Poisoned, want: 4},
+		{name: "prone attackers have disadvantage", condition: tags.Prone, want: 4},
+		{name: "prone targets are easy to hit up close", condition: tags.Prone, onTarget: true, want: 17},
+		{name: "prone targets are hard to hit from afar", condition: tags.Prone, onTarget: true, distance: 3, want: 4},
+		{name: "restrained attackers have disadvantage", condition: tags.Restrained, want: 4},
+		{name: "attacks against the stunned have advantage", condition: tags.Stunned, onTarget: true, want: 17},
+		{name: "grappled attackers have disadvantage", condition: tags.Grappled, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance := tt.distance
+			if distance == 0 {
+				distance = 1
+			}
+			f := newFixture(4, 17)
+			attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+			target := f.actor("target", "players", grid.Position{X: distance, Y: 0})
+			holder := attacker
+			if tt.onTarget {
+				holder = target
+			}
+			holder.AddCondition(tt.condition, condition())
+
+			die, _ := attackDie(attacker, target)
+
+			assert.Equal(t, tt.want, die)
+		})
+	}
+
+	t.Run("without conditions the first die is kept", func(t *testing.T) {
+		f := newFixture(4, 17)
+		attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+		target := f.actor("target", "players", grid.Position{X: 1, Y: 0})
+		die, _ := attackDie(attacker, target)
+		assert.Equal(t, 4, die)
+	})
+
+	t.Run("grappled attackers hit their grappler normally", func(t *testing.T) {
+		f := newFixture(4, 17)
+		attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+		target := f.actor("target", "players", grid.Position{X: 1, Y: 0})
+		grapple := condition()
+		grapple.Source = target
+		attacker.AddCondition(tags.Grappled, grapple)
+
+		die, _ := attackDie(attacker, target)
+		assert.Equal(t, 4, die)
+	})
+}
+
+func TestConditions_Paralyzed(t *testing.T) {
+	t.Run("hits from within 5 feet are critical", func(t *testing.T) {
+		f := newFixture(12, 13)
+		attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+		target := f.actor("target", "players", grid.Position{X: 1, Y: 0})
+		target.AddCondition(tags.Paralyzed, condition())
+
+		_, result := attackDie(attacker, target)
+
+		assert.True(t, result.Success)
+		assert.True(t, result.Critical)
+	})
+
+	t.Run("hits from further away are not", func(t *testing.T) {
+		f := newFixture(12, 13)
+		attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+		target := f.actor("target", "players", grid.Position{X: 3, Y: 0})
+		target.AddCondition(tags.Paralyzed, condition())
+
+		_, result := attackDie(attacker, target)
+
+		assert.True(t, result.Success)
+		assert.False(t, result.Critical)
+	})
+
+	t.Run("strength and dexterity saves fail", func(t *testing.T) {
+		f := newFixture(20, 20)
+		target := f.actor("target", "players", grid.Position{X: 1, Y: 0})
+		target.AddCondition(tags.Paralyzed, condition())
+
+		assert.False(t, target.SaveThrow(tags.AttributeDexterity, 5).Success)
+		assert.True(t, target.SaveThrow(tags.AttributeWisdom, 5).Success)
+	})
+
+	t.Run("can't act or move", func(t *testing.T) {
+		f := newFixture()
+		target := f.actor("target", "players", grid.Position{X: 1, Y: 0})
+		target.AddCondition(tags.Paralyzed, condition())
+		target.StartTurn()
+
+		assert.False(t, target.CanAct())
+		assert.Equal(t, 0, target.Resources.Remaining(tags.ResourceWalkSpeed))
+
+		target.RemoveCondition(tags.Paralyzed, nil)
+		assert.True(t, target.CanAct())
+	})
+}
+
+func TestConditions_Restrained(t *testing.T) {
+	f := newFixture(4, 17)
+	target := f.actor("target", "players", grid.Position{X: 1, Y: 0})
+	target.AddCondition(tags.Restrained, condition())
+	target.StartTurn()
+
+	target.Move(grid.Position{X: 2, Y: 0}, nil)
+
+	assert.Equal(t, grid.Position{X: 1, Y: 0}, target.Position)
+	assert.False(t, target.SaveThrow(tags.AttributeDexterity, 10).Success)
+}
+
+func TestConditions_Petrified(t *testing.T) {
+	f := newFixture()
+	target := f.actor("target", "players", grid.Position{X: 1, Y: 0})
+	target.AddCondition(tags.Petrified, condition())
+
+	traits := target.EffectiveDamageTraits()
+
+	assert.True(t, traits.IsResistant(tag.ContainerFromTag(tags.Fire)))
+	assert.True(t, traits.IsImmune(tag.ContainerFromTag(tags.Poison)))
+}
+
+func TestConditions_Charmed(t *testing.T) {
+	f := newFixture()
+	attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+	target := f.actor("target", "players", grid.Position{X: 1, Y: 0})
+	charm := condition()
+	charm.Source = target
+	attacker.AddCondition(tags.Charmed, charm)
+
+	assert.False(t, attacker.CanAttack(target))
+	assert.True(t, target.CanAttack(attacker))
+}
+
+func TestConditions_Frightened(t *testing.T) {
+	f := newFixture(4, 17)
+	attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+	target := f.actor("target", "players", grid.Position{X: 3, Y: 0})
+	fear := condition()
+	fear.Source = target
+	attacker.AddCondition(tags.Frightened, fear)
+	attacker.StartTurn()
+
+	attacker.Move(grid.Position{X: 1, Y: 0}, nil)
+	assert.Equal(t, grid.Position{X: 0, Y: 0}, attacker.Position)
+
+	attacker.Move(grid.Position{X: 0, Y: 1}, nil)
+	assert.Equal(t, grid.Position{X: 0, Y: 1}, attacker.Position)
+
+	die, _ := attackDie(attacker, target)
+	assert.Equal(t, 4, die)
+}
+
+func TestConditions_Exhaustion(t *testing.T) {
+	exhaustion := condition()
+
+	t.Run("penalizes d20 tests and speed", func(t *testing.T) {
+		f := newFixture(10, 10)
+		attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+		target := f.actor("target", "players", grid.Position{X: 1, Y: 0})
+		attacker.ChangeConditionLevel(tags.Exhaustion, 2, exhaustion)
+		attacker.StartTurn()
+
+		plain := target.SaveThrow(tags.AttributeDexterity, 10)
+		tired := attacker.SaveThrow(tags.AttributeDexterity, 10)
+
+		assert.Equal(t, plain.Value-4, tired.Value)
+		assert.Equal(t, 4, attacker.Resources.Remaining(tags.ResourceWalkSpeed))
+	})
+
+	t.Run("a long rest removes one level", func(t *testing.T) {
+		f := newFixture()
+		attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+		attacker.ChangeConditionLevel(tags.Exhaustion, 1, exhaustion)
+		attacker.HitPoints = 3
+
+		attacker.LongRest()
+
+		assert.Equal(t, 0, attacker.ConditionLevel(tags.Exhaustion))
+		assert.False(t, attacker.HasCondition(tags.Exhaustion, nil))
+		assert.Equal(t, 10, attacker.HitPoints)
+	})
+
+	t.Run("a long rest wakes an actor at 0 hit points and ends temporary hit points", func(t *testing.T) {
+		f := newFixture()
+		attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+		attacker.GrantTemporaryHitPoints(5, "test")
+		attacker.HitPoints = 0
+		attacker.AddCondition(tags.Unconscious, condition())
+		attacker.AddCondition(tags.Stable, condition())
+
+		attacker.LongRest()
+
+		assert.Equal(t, 10, attacker.HitPoints)
+		assert.Equal(t, 0, attacker.TemporaryHitPoints)
+		assert.False(t, attacker.HasCondition(tags.Unconscious, nil))
+		assert.False(t, attacker.HasCondition(tags.Stable, nil))
+	})
+
+	t.Run("level 6 kills", func(t *testing.T) {
+		f := newFixture()
+		attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+		attacker.ChangeConditionLevel(tags.Exhaustion, 5, exhaustion)
+		assert.False(t, attacker.IsDead())
+
+		attacker.ChangeConditionLevel(tags.Exhaustion, 1, exhaustion)
+		assert.True(t, attacker.IsDead())
+	})
+
+	t.Run("levels stop at 6", func(t *testing.T) {
+		f := newFixture()
+		attacker := f.actor("attacker", "players", grid.Position{X: 0, Y: 0})
+		attacker.ChangeConditionLevel(tags.Exhaustion, 8, exhaustion)
+
+		assert.Equal(t, 6, attacker.ConditionLevel(tags.Exhaustion))
+	})
+}
@@ -0,0 +1,99 @@
+package ruleset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core/tags"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+	"anvil/internal/tag"
+)
+
+func TestFightingStyleGreatWeapon(t *testing.T) {
+	t.Run("treats low damage dice of two-handed melee weapons as 3", func(t *testing.T) {
+		f := newFixture(15, 1, 2)
+		fighter := f.actor("Fighter", "players", grid.Position{X: 0, Y: 5})
+		fighter.AddEffect(f.registry.NewEffect("fighting-style-great-weapon", nil))
+		fighter.Equip(f.registry.NewItem("greataxe", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 1, Y: 5})
+
+		findAction(fighter, "Attack with Great Axe").Perform([]grid.Position{target.Position})
+
+		assert.Equal(t, 4, target.HitPoints)
+	})
+
+	t.Run("does not affect ranged weapons", func(t *testing.T) {
+		f := newFixture(15, 1)
+		fighter := f.actor("Fighter", "players", grid.Position{X: 0, Y: 5}, func(def *loader.ActorDefinition) {
+			def.Resources.Ammunition = 1
+		})
+		fighter.AddEffect(f.registry.NewEffect("fighting-style-great-weapon", nil))
+		fighter.Equip(f.registry.NewItem("shortbow", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 5, Y: 5})
+
+		findAction(fighter, "Shoot Shortbow").Perform([]grid.Position{target.Position})
+
+		assert.Equal(t, 9, target.HitPoints)
+	})
+
+	t.Run("does not affect one-handed melee weapons", func(t *testing.T) {
+		f := newFixture(15, 1)
+		fighter := f.actor("Fighter", "players", grid.Position{X: 0, Y: 5})
+		fighter.AddEffect(f.registry.NewEffect("fighting-style-great-weapon", nil))
+		fighter.Equip(f.registry.NewItem("dagger", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 1, Y: 5})
+
+		findAction(fighter, "Attack with Dagger").Perform([]grid.Position{target.Position})
+
+		assert.Equal(t, 9, target.HitPoints)
+	})
+}
+
+func TestSavageAttacker(t *testing.T) {
+	t.Run("rolls the weapon's damage dice twice and keeps the better total", func(t *testing.T) {
+		f := newFixture(15, 8, 1, 2, 6)
+		fighter := f.actor("Fighter", "players", grid.Position{X: 0, Y: 5})
+		fighter.AddEffect(f.registry.NewEffect("savage-attacker", nil))
+		fighter.Equip(f.registry.NewItem("flamingsword", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 1, Y: 5})
+
+		findAction(fighter, "Attack with Flaming Sword").Perform([]grid.Position{target.Position})
+
+		assert.Equal(t, 1, target.HitPoints)
+	})
+
+	t.Run("works once per turn", func(t *testing.T) {
+		// the first hit keeps the better of 1 and 4, the second one in the same turn only rolls once
+		f := newFixture(15, 1, 4, 15, 1, 15, 1, 4)
+		fighter := f.actor("Fighter", "players", grid.Position{X: 0, Y: 5})
+		fighter.AddEffect(f.registry.NewEffect("savage-attacker", nil))
+		fighter.Equip(f.registry.NewItem("dagger", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 1, Y: 5})
+		attack := findAction(fighter, "Attack with Dagger")
+
+		attack.Perform([]grid.Position{target.Position})
+		fighter.Resources.Reset() // like an extra attack
+		attack.Perform([]grid.Position{target.Position})
+		assert.Equal(t, 5, target.HitPoints)
+
+		f.encounter.Turn++
+		fighter.Resources.Reset()
+		attack.Perform([]grid.Position{target.Position})
+		assert.Equal(t, 1, target.HitPoints)
+	})
+}
+
+func TestLucky(t *testing.T) {
+	t.Run("rerolls a 1 on ability checks", func(t *testing.T) {
+		f := newFixture(1, 15)
+		lucky := f.actor("Lucky", "players", grid.Position{})
+		lucky.AddEffect(f.registry.NewEffect("lucky", nil))
+
+		result := lucky.AbilityCheck(tags.AttributeStrength, tag.Tag{}, 10)
+
+		assert.True(t, result.Success)
+		assert.Equal(t, 15, result.Value)
+	})
+}
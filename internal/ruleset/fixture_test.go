@@ -0,0 +1,84 @@
+package ruleset
+
+import (
+	"anvil/internal/core"
+	"anvil/internal/eventbus"
+	"anvil/internal/expression"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+)
+
+type sequenceRoller struct {
+	values []int
+	index  int
+}
+
+func (r *sequenceRoller) Roll(_ int) int {
+	if r.index >= len(r.values) {
+		return 1
+	}
+
+	value := r.values[r.index]
+	r.index++
+	return value
+}
+
+// fixture is a world that rolls the given sequence, with an encounter its actors join
+type fixture struct {
+	registry   *Registry
+	world      *core.World
+	encounter  *core.Encounter
+	dispatcher *eventbus.Dispatcher
+}
+
+func newFixture(rolls ...int) fixture {
+	world := core.NewWorld(loader.WorldDefinition{Width: 20, Height: 10})
+	world.SetRoller(&sequenceRoller{values: rolls})
+	return fixture{
+		registry:   NewRegistry(),
+		world:      world,
+		encounter:  &core.Encounter{World: world},
+		dispatcher: &eventbus.Dispatcher{},
+	}
+}
+
+// actor adds an actor with 10 hit points and average attributes, changed by updates in order
+func (f fixture) actor(name string, team string, pos grid.Position, updates ...func(def *loader.ActorDefinition)) *core.Actor {
+	def := loader.ActorDefinition{
+		Name:         name,
+		Team:         team,
+		HitPoints:    10,
+		MaxHitPoints: 10,
+		Attributes:   loader.AttributesDefinition{Strength: 10, Dexterity: 10, Constitution: 10},
+		Resources:    loader.ResourcesDefinition{WalkSpeed: 6},
+	}
+	for _, update := range updates {
+		update(&def)
+	}
+
+	actor := f.registry.CreateActorFromDefinition(f.dispatcher, f.world, pos, def)
+	f.encounter.AddActor(actor)
+	return actor
+}
+
+func findAction(actor *core.Actor, name string) core.Action {
+	for _, action := range actor.Actions {
+		if action.Name() == name {
+			return action
+		}
+	}
+
+	return nil
+}
+
+// attackRoll returns the last attack roll attacker makes during attack
+func attackRoll(attacker *core.Actor, attack func()) *expression.Expression {
+	var roll *expression.Expression
+	spy := &core.Effect{Name: "spy"}
+	core.On(spy, func(s *core.PostAttackRoll) { roll = s.Result })
+	attacker.AddEffect(spy)
+	defer attacker.Effects.Remove(spy)
+
+	attack()
+	return roll
+}
@@ -0,0 +1,193 @@
+package ruleset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+	"anvil/internal/expression"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+)
+
+// archery gives a fixture actor an archer's dexterity and ammunition to shoot
+func archery(ammunition int) func(def *loader.ActorDefinition) {
+	return func(def *loader.ActorDefinition) {
+		def.Attributes.Dexterity = 16
+		def.Resources.Ammunition = ammunition
+	}
+}
+
+// shoot performs the action against target and returns the attack roll
+func shoot(archer *core.Actor, action core.Action, target *core.Actor) *expression.Expression {
+	return attackRoll(archer, func() { action.Perform([]grid.Position{target.Position}) })
+}
+
+func disadvantage(roll *expression.Expression) []string {
+	return roll.Components[0].(*expression.D20Component).Disadvantage()
+}
+
+func sources(roll *expression.Expression) []string {
+	names := make([]string, 0, len(roll.Components))
+	for _, c := range roll.Components {
+		names = append(names, c.Source())
+	}
+	return names
+}
+
+func TestRangedAction(t *testing.T) {
+	t.Run("shoots within normal range and spends ammunition", func(t *testing.T) {
+		f := newFixture(15, 4)
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(2))
+		archer.Equip(f.registry.NewItem("shortbow", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 10, Y: 5})
+		action := findAction(archer, "Shoot Shortbow")
+
+		assert.Contains(t, action.ValidPositions(archer.Position), target.Position)
+		roll := shoot(archer, action, target)
+
+		assert.Empty(t, disadvantage(roll))
+		assert.Contains(t, sources(roll), "Attribute Modifier (Dexterity)")
+		assert.Equal(t, 3, target.HitPoints)
+		assert.Equal(t, 1, archer.Resources.Remaining(tags.ResourceAmmunition))
+	})
+
+	t.Run("has disadvantage beyond normal range", func(t *testing.T) {
+		f := newFixture()
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(1))
+		archer.Equip(f.registry.NewItem("shortbow", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 18, Y: 5})
+
+		roll := shoot(archer, findAction(archer, "Shoot Shortbow"), target)
+
+		assert.Equal(t, []string{"Long Range"}, disadvantage(roll))
+	})
+
+	t.Run("has disadvantage next to a hostile creature that can act", func(t *testing.T) {
+		f := newFixture()
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(2))
+		archer.Equip(f.registry.NewItem("shortbow", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 5, Y: 5})
+		adjacent := f.actor("Adjacent", "enemies", grid.Position{X: 1, Y: 6})
+
+		roll := shoot(archer, findAction(archer, "Shoot Shortbow"), target)
+		assert.Equal(t, []string{"Hostile Creature Adjacent"}, disadvantage(roll))
+
+		adjacent.AddCondition(tags.Stunned, condition())
+		archer.StartTurn()
+		roll = shoot(archer, findAction(archer, "Shoot Shortbow"), target)
+		assert.Empty(t, disadvantage(roll))
+	})
+
+	t.Run("needs line of sight", func(t *testing.T) {
+		f := newFixture()
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(1))
+		archer.Equip(f.registry.NewItem("shortbow", nil))
+		for y := range 10 {
+			f.world.At(grid.Position{X: 3, Y: y}).Tile = core.Wall
+		}
+		target := f.actor("Target", "enemies", grid.Position{X: 6, Y: 5})
+
+		assert.NotContains(t, findAction(archer, "Shoot Shortbow").ValidPositions(archer.Position), target.Position)
+	})
+
+	t.Run("cannot shoot without ammunition", func(t *testing.T) {
+		f := newFixture()
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(0))
+		archer.Equip(f.registry.NewItem("shortbow", nil))
+		f.actor("Target", "enemies", grid.Position{X: 5, Y: 5})
+
+		assert.Empty(t, findAction(archer, "Shoot Shortbow").ValidPositions(archer.Position))
+	})
+
+	t.Run("thrown weapons can be used in melee and at range", func(t *testing.T) {
+		f := newFixture()
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(0))
+		archer.Equip(f.registry.NewItem("dagger", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 4, Y: 5})
+
+		assert.NotNil(t, findAction(archer, "Attack with Dagger"))
+		assert.Contains(t, findAction(archer, "Throw Dagger").ValidPositions(archer.Position), target.Position)
+	})
+
+	t.Run("ranges count diagonal squares like reach", func(t *testing.T) {
+		f := newFixture()
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(0))
+		archer.Equip(f.registry.NewItem("dagger", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 4, Y: 9})
+
+		roll := shoot(archer, findAction(archer, "Throw Dagger"), target)
+
+		assert.Empty(t, disadvantage(roll))
+	})
+
+	t.Run("a thrown weapon leaves the thrower's hands", func(t *testing.T) {
+		f := newFixture()
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(0))
+		archer.Equip(f.registry.NewItem("dagger", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 3, Y: 5})
+
+		shoot(archer, findAction(archer, "Throw Dagger"), target)
+
+		assert.Empty(t, archer.Equipped)
+		assert.Nil(t, findAction(archer, "Throw Dagger"))
+		assert.Nil(t, findAction(archer, "Attack with Dagger"))
+	})
+
+	t.Run("throwing one of two daggers keeps the other's actions", func(t *testing.T) {
+		f := newFixture()
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(0))
+		archer.Equip(f.registry.NewItem("dagger", nil))
+		archer.Equip(f.registry.NewItem("dagger", nil))
+		target := f.actor("Target", "enemies", grid.Position{X: 3, Y: 5})
+
+		shoot(archer, findAction(archer, "Throw Dagger"), target)
+
+		names := make([]string, 0)
+		for _, action := range archer.Actions {
+			names = append(names, action.Name())
+		}
+		assert.Len(t, archer.Equipped, 1)
+		assert.ElementsMatch(t, []string{"Move", "Attack with Dagger", "Throw Dagger"}, names)
+	})
+
+	t.Run("ranged attacks are never melee attacks", func(t *testing.T) {
+		f := newFixture()
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(0))
+		action := f.registry.NewAction("ranged", archer, map[string]interface{}{
+			"definition": loader.RangedActionDefinition{
+				Name:          "Throw Spear",
+				Tags:          []string{"Melee", "Item.Weapon.Thrown"},
+				Range:         4,
+				DamageFormula: "1d6",
+				DamageType:    "piercing",
+			},
+		})
+
+		assert.False(t, action.Tags().HasTag(tags.Melee))
+		assert.True(t, action.Tags().HasTag(tags.Ranged))
+	})
+
+	t.Run("thrown attacks keep the melee ability", func(t *testing.T) {
+		f := newFixture()
+		archer := f.actor("Archer", "players", grid.Position{X: 0, Y: 5}, archery(0))
+		target := f.actor("Target", "enemies", grid.Position{X: 3, Y: 5})
+		action := f.registry.NewAction("ranged", archer, map[string]interface{}{
+			"definition": loader.RangedActionDefinition{
+				Name:          "Throw Handaxe",
+				Cost:          map[string]int{"Actor.Resource.Action": 1},
+				Tags:          []string{"Item.Weapon.Simple", "Item.Weapon.Thrown"},
+				Range:         4,
+				LongRange:     12,
+				DamageFormula: "1d6",
+				DamageType:    "slashing",
+			},
+		})
+
+		roll := shoot(archer, action, target)
+
+		assert.Contains(t, sources(roll), "Attribute Modifier (Strength)")
+	})
+}
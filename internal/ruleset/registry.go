@@ -2,6 +2,8 @@ package ruleset
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"anvil/internal/core"
 	"anvil/internal/core/tags"
@@ -132,6 +134,9 @@ func (r *Registry) CreateActorFromDefinition(
 	r.addBasicEffects(actor)
 	r.applyTeamConfiguration(actor, definition.Team)
 	actor.AddAction(r.NewAction("move", actor, nil))
+	for _, spell := range actor.Spellbook.Known {
+		actor.Spellbook.Bind(spell, r.NewAction(spell, actor, nil))
+	}
 	return actor
 }
 
@@ -139,7 +144,10 @@ func (r *Registry) addBasicEffects(actor *core.Actor) {
 	actor.AddEffect(r.NewEffect("attribute-modifier", nil))
 	actor.AddEffect(r.NewEffect("proficiency-modifier", nil))
 	actor.AddEffect(r.NewEffect("critical", nil))
-	actor.AddEffect(r.NewEffect("attack-of-opportunity", nil))
+	actor.AddEffect(r.NewEffect("ranged-attack", nil))
+	for _, archetype := range slices.Sorted(maps.Keys(conditionEffects)) {
+		actor.AddEffect(r.NewEffect(archetype, nil))
+	}
 }
 
 func (r *Registry) applyTeamConfiguration(actor *core.Actor, team string) {
@@ -38,16 +38,42 @@ func TestNewRegistry(t *testing.T) {
 
 	// Check that basic actions are registered
 	assert.True(t, registry.HasAction("move"))
+	assert.True(t, registry.HasAction("ranged"))
+	assert.True(t, registry.HasAction("save-spell"))
+	assert.True(t, registry.HasAction("burning-hands"))
+	assert.True(t, registry.HasAction("thunderwave"))
+	assert.True(t, registry.HasAction("sacred-flame"))
 
 	// Check that basic effects are registered
 	assert.True(t, registry.HasEffect("critical"))
 	assert.True(t, registry.HasEffect("death"))
 	assert.True(t, registry.HasEffect("death-saving-throw"))
-	assert.True(t, registry.HasEffect("attack-of-opportunity"))
 	assert.True(t, registry.HasEffect("proficiency-modifier"))
 	assert.True(t, registry.HasEffect("attribute-modifier"))
+	assert.True(t, registry.HasEffect("ranged-attack"))
 	assert.True(t, registry.HasEffect("undead-fortitude"))
+	assert.True(t, registry.HasEffect("damage-resistance"))
+	assert.True(t, registry.HasEffect("lucky"))
+	assert.True(t, registry.HasEffect("savage-attacker"))
 	assert.True(t, registry.HasEffect("fighting-style-defense"))
+	assert.True(t, registry.HasEffect("improved-critical"))
+	assert.True(t, registry.HasEffect("fighting-style-great-weapon"))
+	assert.True(t, registry.HasEffect("jack-of-all-trades"))
+	assert.True(t, registry.HasEffect("condition-blinded"))
+	assert.True(t, registry.HasEffect("condition-charmed"))
+	assert.True(t, registry.HasEffect("condition-deafened"))
+	assert.True(t, registry.HasEffect("condition-exhaustion"))
+	assert.True(t, registry.HasEffect("condition-frightened"))
+	assert.True(t, registry.HasEffect("condition-grappled"))
+	assert.True(t, registry.HasEffect("condition-incapacitated"))
+	assert.True(t, registry.HasEffect("condition-invisible"))
+	assert.True(t, registry.HasEffect("condition-paralyzed"))
+	assert.True(t, registry.HasEffect("condition-petrified"))
+	assert.True(t, registry.HasEffect("condition-poisoned"))
+	assert.True(t, registry.HasEffect("condition-prone"))
+	assert.True(t, registry.HasEffect("condition-restrained"))
+	assert.True(t, registry.HasEffect("condition-stunned"))
+	assert.True(t, registry.HasEffect("condition-unconscious"))
 
 	// Check that basic items are registered
 	assert.True(t, registry.HasItem("chainmail"))
@@ -58,6 +84,7 @@ func TestNewRegistry(t *testing.T) {
 	// Check that some weapons are loaded from YAML
 	assert.True(t, registry.HasItem("dagger"))
 	assert.True(t, registry.HasItem("greataxe"))
+	assert.True(t, registry.HasItem("shortbow"))
 }
 
 func TestRegistry_ActionRegistration(t *testing.T) {
@@ -258,8 +285,9 @@ type MockItem struct {
 	name string
 }
 
-func (m *MockItem) Name() string         { return m.name }
-func (m *MockItem) Archetype() string    { return "mock-item" }
-func (m *MockItem) ID() string           { return "mock-id" }
-func (m *MockItem) Tags() *tag.Container { tags := tag.ContainerFromTag(); return &tags }
-func (m *MockItem) OnEquip(*core.Actor)  {}
+func (m *MockItem) Name() string          { return m.name }
+func (m *MockItem) Archetype() string     { return "mock-item" }
+func (m *MockItem) ID() string            { return "mock-id" }
+func (m *MockItem) Tags() *tag.Container  { tags := tag.ContainerFromTag(); return &tags }
+func (m *MockItem) OnEquip(*core.Actor)   {}
+func (m *MockItem) OnUnequip(*core.Actor) {}
@@ -1,7 +1,11 @@
 package ruleset
 
 import (
+	"fmt"
+
+	"anvil/data"
 	"anvil/internal/core"
+	"anvil/internal/core/tags"
 	"anvil/internal/eventbus"
 	"anvil/internal/grid"
 	"anvil/internal/loader"
@@ -12,6 +16,7 @@ import (
 func SeedRegistry(registry *Registry) {
 	registerBasicActions(registry)
 	registerBasicEffects(registry)
+	registerConditionEffects(registry)
 	registerSharedEffects(registry)
 	registerClassEffects(registry)
 	registerItems(registry)
@@ -30,6 +35,48 @@ func registerBasicActions(registry *Registry) {
 		}
 		return basic.NewMeleeActionFromDefinition(owner, def)
 	})
+
+	registry.RegisterAction("ranged", func(owner *core.Actor, options map[string]interface{}) core.Action {
+		def, ok := options["definition"].(loader.RangedActionDefinition)
+		if !ok {
+			panic("ranged action requires RangedActionDefinition")
+		}
+		return basic.NewRangedActionFromDefinition(owner, def)
+	})
+
+	registry.RegisterAction("save-spell", func(owner *core.Actor, options map[string]interface{}) core.Action {
+		def, ok := options["definition"].(loader.SpellActionDefinition)
+		if !ok {
+			panic("save-spell action requires SpellActionDefinition")
+		}
+		return basic.NewSaveSpellActionFromDefinition(owner, def, spellRider(registry, def))
+	})
+
+	spells, err := loader.LoadSpellDefinitions(data.Ruleset, "ruleset/spells")
+	if err != nil {
+		panic(fmt.Sprintf("cannot load spells: %v", err))
+	}
+	RegisterSpells(registry, spells)
+}
+
+// RegisterSpells adds every spell as its own action archetype, such as the ones read by loader.LoadSpellDefinitions
+func RegisterSpells(registry *Registry, defs map[string]loader.SpellActionDefinition) {
+	for archetype, spellDef := range defs {
+		def := spellDef
+		registry.RegisterAction(archetype, func(owner *core.Actor, _ map[string]interface{}) core.Action {
+			return basic.NewSaveSpellActionFromDefinition(owner, def, spellRider(registry, def))
+		})
+	}
+}
+
+func spellRider(registry *Registry, def loader.SpellActionDefinition) func() *core.Effect {
+	if def.Rider == "" {
+		return nil
+	}
+
+	return func() *core.Effect {
+		return registry.NewEffect(def.Rider, nil)
+	}
 }
 
 func registerBasicEffects(registry *Registry) {
@@ -45,10 +92,6 @@ func registerBasicEffects(registry *Registry) {
 		return basic.NewDeathSavingThrowEffect()
 	})
 
-	registry.RegisterEffect("attack-of-opportunity", func(_ map[string]interface{}) *core.Effect {
-		return basic.NewAttackOfOpportunityEffect()
-	})
-
 	registry.RegisterEffect("proficiency-modifier", func(_ map[string]interface{}) *core.Effect {
 		return basic.NewProficiencyModifierEffect()
 	})
@@ -56,18 +99,81 @@ func registerBasicEffects(registry *Registry) {
 	registry.RegisterEffect("attribute-modifier", func(_ map[string]interface{}) *core.Effect {
 		return basic.NewAttributeModifierEffect()
 	})
+
+	registry.RegisterEffect("ranged-attack", func(_ map[string]interface{}) *core.Effect {
+		return basic.NewRangedAttackEffect()
+	})
+}
+
+// conditionEffects implement the mechanics of each condition, every actor carries all of them
+var conditionEffects = map[string]func() *core.Effect{
+	"condition-blinded":       basic.NewBlindedEffect,
+	"condition-charmed":       basic.NewCharmedEffect,
+	"condition-deafened":      basic.NewDeafenedEffect,
+	"condition-exhaustion":    basic.NewExhaustionEffect,
+	"condition-frightened":    basic.NewFrightenedEffect,
+	"condition-grappled":      basic.NewGrappledEffect,
+	"condition-incapacitated": basic.NewIncapacitatedEffect,
+	"condition-invisible":     basic.NewInvisibleEffect,
+	"condition-paralyzed":     basic.NewParalyzedEffect,
+	"condition-petrified":     basic.NewPetrifiedEffect,
+	"condition-poisoned":      basic.NewPoisonedEffect,
+	"condition-prone":         basic.NewProneEffect,
+	"condition-restrained":    basic.NewRestrainedEffect,
+	"condition-stunned":       basic.NewStunnedEffect,
+	"condition-unconscious":   basic.NewUnconsciousEffect,
+}
+
+func registerConditionEffects(registry *Registry) {
+	for archetype, factory := range conditionEffects {
+		registry.RegisterEffect(archetype, func(_ map[string]interface{}) *core.Effect {
+			return factory()
+		})
+	}
 }
 
 func registerSharedEffects(registry *Registry) {
 	registry.RegisterEffect("undead-fortitude", func(_ map[string]interface{}) *core.Effect {
 		return basic.NewUndeadFortitudeEffect()
 	})
+
+	registry.RegisterEffect("damage-resistance", func(options map[string]interface{}) *core.Effect {
+		kind, ok := options["kind"].(string)
+		if !ok {
+			panic("damage-resistance effect requires a damage kind")
+		}
+		return basic.NewDamageResistanceEffect(tags.DamageKindFromString(kind))
+	})
+
+	registry.RegisterEffect("lucky", func(_ map[string]interface{}) *core.Effect {
+		return basic.NewLuckyEffect()
+	})
+
+	registry.RegisterEffect("savage-attacker", func(_ map[string]interface{}) *core.Effect {
+		return basic.NewSavageAttackerEffect()
+	})
 }
 
 func registerClassEffects(registry *Registry) {
 	registry.RegisterEffect("fighting-style-defense", func(_ map[string]interface{}) *core.Effect {
 		return basic.NewFightingStyleDefense()
 	})
+
+	registry.RegisterEffect("fighting-style-great-weapon", func(_ map[string]interface{}) *core.Effect {
+		return basic.NewFightingStyleGreatWeapon()
+	})
+
+	registry.RegisterEffect("jack-of-all-trades", func(_ map[string]interface{}) *core.Effect {
+		return basic.NewJackOfAllTradesEffect()
+	})
+
+	registry.RegisterEffect("improved-critical", func(options map[string]interface{}) *core.Effect {
+		threshold, ok := options["threshold"].(int)
+		if !ok {
+			threshold = 19
+		}
+		return basic.NewImprovedCriticalEffect(threshold)
+	})
 }
 
 func registerItems(registry *Registry) {
@@ -23,6 +23,7 @@ func ZombieDefinition(name string) loader.ActorDefinition {
 		Resources: loader.ResourcesDefinition{
 			WalkSpeed: 4,
 		},
+		Immunities: []string{"poison"},
 	}
 }
 
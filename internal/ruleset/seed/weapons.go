@@ -10,8 +10,20 @@ func WeaponDefinitions() map[string]loader.WeaponDefinition {
 			Damage: []loader.DamageData{
 				{Formula: "1d4", Kind: "piercing"},
 			},
-			Tags:  []string{"light", "finesse", "thrown"},
-			Reach: 1,
+			Tags:      []string{"Item.Weapon.Simple", "Item.Weapon.Light", "Item.Weapon.Finesse", "Item.Weapon.Thrown"},
+			Reach:     1,
+			Range:     4,
+			LongRange: 12,
+		},
+		"shortbow": {
+			Archetype: "shortbow",
+			Name:      "Shortbow",
+			Damage: []loader.DamageData{
+				{Formula: "1d6", Kind: "piercing"},
+			},
+			Tags:      []string{"Item.Weapon.Simple", "Item.Weapon.Ammunition"},
+			Range:     16,
+			LongRange: 64,
 		},
 		"greataxe": {
 			Archetype: "greataxe",
@@ -19,7 +31,7 @@ func WeaponDefinitions() map[string]loader.WeaponDefinition {
 			Damage: []loader.DamageData{
 				{Formula: "2d6", Kind: "slashing"},
 			},
-			Tags:  []string{"heavy", "two-handed"},
+			Tags:  []string{"Item.Weapon.Martial.Axe", "Item.Weapon.Heavy", "Item.Weapon.TwoHanded"},
 			Reach: 1,
 		},
 		"flamingsword": {
@@ -29,7 +41,7 @@ func WeaponDefinitions() map[string]loader.WeaponDefinition {
 				{Formula: "1d8", Kind: "slashing"},
 				{Formula: "1d6", Kind: "fire"},
 			},
-			Tags:  []string{"versatile"},
+			Tags:  []string{"Item.Weapon.Martial", "Item.Weapon.Versatile"},
 			Reach: 1,
 		},
 		"zombie_slam": {
@@ -0,0 +1,267 @@
+package ruleset
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"anvil/data"
+	"anvil/internal/core"
+	"anvil/internal/core/tags"
+	"anvil/internal/eventbus"
+	"anvil/internal/grid"
+	"anvil/internal/loader"
+)
+
+// sturdy gives a fixture actor enough hit points to take a spell's full damage
+func sturdy(def *loader.ActorDefinition) {
+	def.HitPoints = 30
+	def.MaxHitPoints = 30
+}
+
+// spellcaster makes a sturdy fixture actor an Intelligence caster with two 1st level slots
+func spellcaster(def *loader.ActorDefinition) {
+	sturdy(def)
+	def.SpellCastingSource = "Actor.Attribute.Intelligence"
+	def.Attributes.Intelligence = 16
+	def.Proficiencies.Bonus = 2
+	def.Resources.SpellSlot1 = 2
+}
+
+func TestSaveSpellAction(t *testing.T) {
+	t.Run("shares one damage roll and halves it on a successful save", func(t *testing.T) {
+		// 3d6 fire rolls 12, then the first target fails and the second succeeds against DC 13
+		f := newFixture(4, 4, 4, 2, 19)
+		caster := f.actor("Wizard", "players", grid.Position{X: 0, Y: 5}, spellcaster)
+		near := f.actor("Near", "enemies", grid.Position{X: 1, Y: 5}, sturdy)
+		far := f.actor("Far", "enemies", grid.Position{X: 3, Y: 5}, sturdy)
+		action := f.registry.NewAction("burning-hands", caster, nil)
+
+		affected := action.AffectedPositions([]grid.Position{{X: 3, Y: 5}})
+		assert.Contains(t, affected, near.Position)
+		assert.Contains(t, affected, far.Position)
+		assert.NotContains(t, affected, caster.Position)
+		action.Perform([]grid.Position{{X: 3, Y: 5}})
+
+		assert.Equal(t, 18, near.HitPoints)
+		assert.Equal(t, 24, far.HitPoints)
+		assert.Equal(t, 1, caster.Resources.Remaining(tags.ResourceSpellSlot1))
+	})
+
+	t.Run("rolls damage with the spell's tags and without the casting modifier", func(t *testing.T) {
+		f := newFixture(4, 4, 4, 2)
+		caster := f.actor("Wizard", "players", grid.Position{X: 0, Y: 5}, spellcaster)
+		target := f.actor("Target", "enemies", grid.Position{X: 1, Y: 5}, sturdy)
+		action := f.registry.NewAction("burning-hands", caster, nil)
+
+		var rolled *core.PreDamageRoll
+		spy := &core.Effect{Name: "spy"}
+		core.On(spy, func(s *core.PreDamageRoll) { rolled = s })
+		caster.AddEffect(spy)
+		action.Perform([]grid.Position{target.Position})
+
+		require.NotNil(t, rolled)
+		assert.True(t, rolled.Tags.HasTag(tags.Spell))
+		assert.True(t, rolled.Tags.HasTag(tags.SomaticComponent))
+		assert.Equal(t, 18, target.HitPoints)
+	})
+
+	t.Run("single target spells deal nothing on a success", func(t *testing.T) {
+		f := newFixture(8, 19)
+		caster := f.actor("Wizard", "players", grid.Position{X: 0, Y: 5}, spellcaster)
+		target := f.actor("Target", "enemies", grid.Position{X: 4, Y: 5}, sturdy)
+		action := f.registry.NewAction("sacred-flame", caster, nil)
+
+		assert.Contains(t, action.ValidPositions(caster.Position), target.Position)
+		assert.NotContains(t, action.ValidPositions(caster.Position), caster.Position)
+		action.Perform([]grid.Position{target.Position})
+
+		assert.Equal(t, 30, target.HitPoints)
+	})
+
+	t.Run("ranges count diagonal squares like reach", func(t *testing.T) {
+		f := newFixture()
+		caster := f.actor("Wizard", "players", grid.Position{X: 0, Y: 5}, spellcaster)
+		target := f.actor("Target", "enemies", grid.Position{X: 6, Y: 9}, sturdy)
+		action := f.registry.NewAction("save-spell", caster, map[string]interface{}{
+			"definition": loader.SpellActionDefinition{
+				Name:  "Command",
+				Cost:  map[string]int{"Actor.Resource.Action": 1},
+				Range: "30 feet",
+				Save:  "wisdom",
+			},
+		})
+
+		assert.Contains(t, action.ValidPositions(caster.Position), target.Position)
+	})
+
+	t.Run("adds the rider to targets that fail", func(t *testing.T) {
+		f := newFixture(1, 1, 2)
+		caster := f.actor("Wizard", "players", grid.Position{X: 0, Y: 5}, spellcaster)
+		f.registry.RegisterEffect("deafening", func(_ map[string]interface{}) *core.Effect {
+			return &core.Effect{Archetype: "deafening", Name: "Deafening"}
+		})
+		target := f.actor("Target", "enemies", grid.Position{X: 1, Y: 5}, sturdy)
+		action := f.registry.NewAction("save-spell", caster, map[string]interface{}{
+			"definition": loader.SpellActionDefinition{
+				Name:          "Thunderclap",
+				Cost:          map[string]int{"Actor.Resource.Action": 1},
+				DamageFormula: "1d6",
+				DamageType:    "thunder",
+				Save:          "constitution",
+				Rider:         "deafening",
+				Area:          loader.AreaDefinition{Shape: "emanation", Size: 5},
+			},
+		})
+
+		assert.Equal(t, []grid.Position{caster.Position}, action.ValidPositions(caster.Position))
+		action.Perform([]grid.Position{caster.Position})
+
+		assert.Equal(t, 29, target.HitPoints)
+		assert.True(t, target.Effects.Has("deafening"))
+	})
+}
+
+func TestSpellcasting(t *testing.T) {
+	t.Run("prepared spells become actions", func(t *testing.T) {
+		f := newFixture()
+		caster := f.actor("Cleric", "players", grid.Position{X: 0, Y: 0}, spellcaster, func(def *loader.ActorDefinition) {
+			def.Spells = loader.SpellsDefinition{Known: []string{"thunderwave"}, Prepared: []string{"sacred-flame"}}
+		})
+
+		names := make([]string, 0)
+		for _, action := range caster.Actions {
+			names = append(names, action.Name())
+		}
+		assert.Contains(t, names, "Sacred Flame")
+		assert.NotContains(t, names, "Thunderwave")
+		assert.True(t, caster.Spellbook.Knows("thunderwave"))
+	})
+
+	t.Run("known cantrips and spells prepared later become actions", func(t *testing.T) {
+		f := newFixture()
+		caster := f.actor("Cleric", "players", grid.Position{X: 0, Y: 0}, spellcaster, func(def *loader.ActorDefinition) {
+			def.Spells = loader.SpellsDefinition{Known: []string{"sacred-flame", "thunderwave"}}
+		})
+		names := func() []string {
+			names := make([]string, 0)
+			for _, action := range caster.Actions {
+				names = append(names, action.Name())
+			}
+			return names
+		}
+
+		assert.Contains(t, names(), "Sacred Flame")
+		assert.NotContains(t, names(), "Thunderwave")
+
+		caster.Spellbook.Prepare("thunderwave")
+		assert.Contains(t, names(), "Thunderwave")
+
+		caster.Spellbook.Unprepare("thunderwave")
+		assert.NotContains(t, names(), "Thunderwave")
+	})
+
+	t.Run("spells carry their component tags", func(t *testing.T) {
+		f := newFixture()
+		caster := f.actor("Wizard", "players", grid.Position{X: 0, Y: 5}, spellcaster)
+		action := f.registry.NewAction("burning-hands", caster, nil)
+
+		assert.True(t, action.Tags().HasTag(tags.VerbalComponent))
+		assert.True(t, action.Tags().HasTag(tags.SomaticComponent))
+		assert.False(t, action.Tags().HasTag(tags.MaterialComponent))
+	})
+
+	t.Run("cannot be cast without a slot of its level", func(t *testing.T) {
+		f := newFixture()
+		caster := f.actor("Cleric", "players", grid.Position{X: 0, Y: 0}, spellcaster, func(def *loader.ActorDefinition) { def.Resources.SpellSlot1 = 0 })
+		action := f.registry.NewAction("burning-hands", caster, nil)
+
+		assert.Empty(t, action.ValidPositions(caster.Position))
+	})
+
+	t.Run("upcasting adds a die per slot level above the spell", func(t *testing.T) {
+		// 3d6 fire rolls 12 and the two upcast dice 5 more each, then the target fails its save
+		f := newFixture(4, 4, 4, 5, 5, 2)
+		target := f.actor("Target", "enemies", grid.Position{X: 1, Y: 0}, sturdy)
+		caster := f.actor("Cleric", "players", grid.Position{X: 0, Y: 0}, spellcaster, func(def *loader.ActorDefinition) { def.Resources.SpellSlot3 = 1 })
+		action := f.registry.NewAction("burning-hands", caster, nil)
+
+		using := false
+		f.dispatcher.Subscribe(eventbus.EventType(core.UseActionEvent{}), func(e eventbus.Event) { using = !e.End })
+		f.world.RequestManager().OnRequest(func(request *core.Request) {
+			assert.True(t, using, "the slot is chosen while the action is used")
+			request.Answer(request.Options[len(request.Options)-1])
+		})
+		action.Perform([]grid.Position{target.Position})
+
+		assert.Equal(t, 8, target.HitPoints)
+		assert.Equal(t, 0, caster.Resources.Remaining(tags.ResourceSpellSlot3))
+		assert.Equal(t, 2, caster.Resources.Remaining(tags.ResourceSpellSlot1))
+	})
+
+	t.Run("cantrips scale with the caster level", func(t *testing.T) {
+		// two d8 at level 5, then the target fails its save
+		f := newFixture(3, 4, 2)
+		target := f.actor("Target", "enemies", grid.Position{X: 2, Y: 0}, sturdy)
+		caster := f.actor("Cleric", "players", grid.Position{X: 0, Y: 0}, spellcaster, func(def *loader.ActorDefinition) { def.Level = 5 })
+		action := f.registry.NewAction("sacred-flame", caster, nil)
+
+		assert.Equal(t, 8, action.AverageDamage())
+		action.Perform([]grid.Position{target.Position})
+
+		assert.Equal(t, 23, target.HitPoints)
+		assert.Equal(t, 2, caster.Resources.Remaining(tags.ResourceSpellSlot1))
+	})
+
+	t.Run("rituals need no slot outside of an encounter", func(t *testing.T) {
+		f := newFixture(2)
+		target := f.actor("Target", "enemies", grid.Position{X: 1, Y: 0}, sturdy)
+		caster := f.actor("Cleric", "players", grid.Position{X: 0, Y: 0}, spellcaster, func(def *loader.ActorDefinition) { def.Resources.SpellSlot1 = 0 })
+		caster.Encounter = nil // fixture actors start in its encounter
+		action := f.registry.NewAction("save-spell", caster, map[string]interface{}{
+			"definition": loader.SpellActionDefinition{
+				Name:   "Alarm Bell",
+				Cost:   map[string]int{"Actor.Resource.Action": 1},
+				Level:  1,
+				Ritual: true,
+				Range:  "30 feet",
+				Save:   "wisdom",
+			},
+		})
+
+		assert.True(t, action.Tags().HasTag(tags.Ritual))
+		assert.Contains(t, action.ValidPositions(caster.Position), target.Position)
+		action.Perform([]grid.Position{target.Position})
+
+		caster.Encounter = f.encounter
+		assert.Empty(t, action.ValidPositions(caster.Position))
+	})
+}
+
+func TestLoadSpellDefinitions(t *testing.T) {
+	defs, err := loader.LoadSpellDefinitions(data.Ruleset, "ruleset/spells")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"burning-hands", "sacred-flame", "thunderwave"}, slices.Collect(maps.Keys(defs)))
+	assert.Equal(t, loader.SpellActionDefinition{
+		Name:          "Burning Hands",
+		Cost:          map[string]int{"Actor.Resource.Action": 1},
+		Level:         1,
+		School:        "evocation",
+		CastingTime:   "action",
+		Range:         "Self",
+		Duration:      "Instantaneous",
+		Components:    []string{"V", "S"},
+		DamageFormula: "3d6",
+		DamageType:    "fire",
+		Upcast:        "1d6",
+		Save:          "dexterity",
+		HalfOnSuccess: true,
+		Area:          loader.AreaDefinition{Shape: "cone", Size: 15},
+	}, defs["burning-hands"])
+	assert.Equal(t, loader.AreaDefinition{Shape: "cube", Size: 15, FromSelf: true}, defs["thunderwave"].Area)
+	assert.Zero(t, defs["sacred-flame"].Level)
+}